@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Run one algorithm on random instances for each pair of item count and
+// capacity fraction, and print a CSV of how long each took. Cells that
+// run past -timeout are marked timed_out and the grid goes on, so the
+// output maps where the algorithm is tractable:
+//
+//	go run . -algorithm bnb -sizes 10,20,30,40 -fractions 0.25,0.5,0.75
+
+const minValue = 1
+const maxValue = 10
+const minWeight = 4
+const maxWeight = 10
+
+var algorithmName = flag.String("algorithm", "bnb",
+	"the algorithm to run: exhaustive, bnb, dp, or greedy")
+var sizes = flag.String("sizes", "10,15,20,25,30",
+	"comma-separated item counts")
+var fractions = flag.String("fractions", "0.25,0.5,0.75",
+	"comma-separated capacities, as fractions of the items' total weight")
+var timeout = flag.Duration("timeout", time.Second,
+	"the most time to give each cell")
+var seed = flag.Int64("seed", 1337,
+	"the seed for each cell's random items")
+
+type Item struct {
+	value, weight int
+}
+
+// The algorithms. Each returns the best value, or ctx.Err() if ctx is done first.
+var algorithms = map[string]func(context.Context, []Item, int) (int, error){
+	"exhaustive": exhaustiveSearch,
+	"bnb":        branchAndBound,
+	"dp":         dynamicProgramming,
+	"greedy":     greedy,
+}
+
+func main() {
+	flag.Parse()
+
+	alg, ok := algorithms[*algorithmName]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown -algorithm %q\n", *algorithmName)
+		os.Exit(2)
+	}
+	numItems, err := parseInts(*sizes)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "-sizes:", err)
+		os.Exit(2)
+	}
+	capacityFractions, err := parseFloats(*fractions)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "-fractions:", err)
+		os.Exit(2)
+	}
+
+	fmt.Println("n,fraction,elapsed,timed_out")
+	for _, n := range numItems {
+		for _, fraction := range capacityFractions {
+			// Every cell gets its own generator, so a cell's items don't
+			// depend on which cells came before it.
+			items := makeItems(rand.New(rand.NewSource(*seed)), n)
+			allowedWeight := int(float64(sumWeights(items)) * fraction)
+
+			ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+			start := time.Now()
+			_, err := alg(ctx, items, allowedWeight)
+			elapsed := time.Since(start)
+			cancel()
+			fmt.Printf("%d,%g,%f,%t\n", n, fraction, elapsed.Seconds(), err != nil)
+		}
+	}
+}
+
+// Parse a comma-separated list of non-negative integers like "10,20,30".
+func parseInts(list string) ([]int, error) {
+	values := []int{}
+	for _, field := range strings.Split(list, ",") {
+		value, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("bad number %q: %w", field, err)
+		}
+		if value < 0 {
+			return nil, fmt.Errorf("%d is negative", value)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// Parse a comma-separated list of non-negative numbers like "0.25,0.5".
+func parseFloats(list string) ([]float64, error) {
+	values := []float64{}
+	for _, field := range strings.Split(list, ",") {
+		value, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad number %q: %w", field, err)
+		}
+		if value < 0 {
+			return nil, fmt.Errorf("%g is negative", value)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// Make some random items using the given generator.
+func makeItems(random *rand.Rand, numItems int) []Item {
+	items := make([]Item, numItems)
+	for i := 0; i < numItems; i++ {
+		items[i] = Item{
+			random.Intn(maxValue-minValue+1) + minValue,
+			random.Intn(maxWeight-minWeight+1) + minWeight}
+	}
+	return items
+}
+
+// Return the total weight of the items.
+func sumWeights(items []Item) int {
+	total := 0
+	for _, item := range items {
+		total += item.weight
+	}
+	return total
+}
+
+// Return the total value of the items.
+func sumValues(items []Item) int {
+	total := 0
+	for _, item := range items {
+		total += item.value
+	}
+	return total
+}
+
+// A search that stops when its context is done.
+type search struct {
+	ctx       context.Context
+	calls     int
+	cancelled bool
+}
+
+// Return true if the search should stop.
+// Checking the context is slow, so only do it every 4096 calls.
+func (s *search) stopped() bool {
+	if s.cancelled {
+		return true
+	}
+	s.calls += 1
+	if s.calls%4096 == 0 && s.ctx.Err() != nil {
+		s.cancelled = true
+	}
+	return s.cancelled
+}
+
+// Try every selection of the items.
+func exhaustiveSearch(ctx context.Context, items []Item, allowedWeight int) (int, error) {
+	s := &search{ctx: ctx}
+	var visit func(next, value, weight int) int
+	visit = func(next, value, weight int) int {
+		if s.stopped() {
+			return 0
+		}
+		if next == len(items) {
+			if weight > allowedWeight {
+				return -1
+			}
+			return value
+		}
+		with := visit(next+1, value+items[next].value, weight+items[next].weight)
+		without := visit(next+1, value, weight)
+		return max(with, without)
+	}
+	best := visit(0, 0, 0)
+	if s.cancelled {
+		return 0, ctx.Err()
+	}
+	return best, nil
+}
+
+// Use branch and bound, pruning branches whose remaining items' total
+// value can't beat the best solution so far.
+func branchAndBound(ctx context.Context, items []Item, allowedWeight int) (int, error) {
+	s := &search{ctx: ctx}
+	bestValue := 0
+	var visit func(next, value, weight, remainingValue int)
+	visit = func(next, value, weight, remainingValue int) {
+		if s.stopped() || value+remainingValue <= bestValue {
+			return
+		}
+		if next == len(items) {
+			bestValue = value
+			return
+		}
+		remainingValue -= items[next].value
+		if weight+items[next].weight <= allowedWeight {
+			visit(next+1, value+items[next].value, weight+items[next].weight, remainingValue)
+		}
+		visit(next+1, value, weight, remainingValue)
+	}
+	visit(0, 0, 0, sumValues(items))
+	if s.cancelled {
+		return 0, ctx.Err()
+	}
+	return bestValue, nil
+}
+
+// Use dynamic programming, one row of the table per item.
+func dynamicProgramming(ctx context.Context, items []Item, allowedWeight int) (int, error) {
+	best := make([]int, allowedWeight+1)
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		// Work downwards so each item is only used once.
+		for w := allowedWeight; w >= item.weight; w-- {
+			best[w] = max(best[w], best[w-item.weight]+item.value)
+		}
+	}
+	return best[allowedWeight], nil
+}
+
+// Add the items in order of decreasing value/weight while they fit.
+// This is quick enough that it never checks ctx.
+func greedy(_ context.Context, items []Item, allowedWeight int) (int, error) {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := items[order[i]], items[order[j]]
+		return a.value*b.weight > b.value*a.weight
+	})
+
+	value, weight := 0, 0
+	for _, i := range order {
+		if weight+items[i].weight <= allowedWeight {
+			value += items[i].value
+			weight += items[i].weight
+		}
+	}
+	return value, nil
+}
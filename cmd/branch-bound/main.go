@@ -1,8 +1,10 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math/rand"
+	"os"
 	"time"
 )
 
@@ -17,12 +19,21 @@ const maxWeight = 10
 
 var allowedWeight int
 
+var capacityFraction = flag.Float64("capacity-fraction", 0.5,
+	"allowed weight as a fraction of the items' total weight")
+var assertOptimal = flag.Int("assert-optimal", -1,
+	"exit non-zero if any algorithm's value differs from this expected optimum")
+
 type Item struct {
 	value, weight int
 	isSelected    bool
 }
 
 // TEST RESULTs:
+// makeItems uses the fixed seed 1337, so everything but the elapsed
+// times is reproducible. TestSeed1337Result checks the branch and bound
+// value and weight below.
+//
 // *** Parameters ***
 // # items: 25
 // Total value: 142
@@ -33,15 +44,23 @@ type Item struct {
 // Elapsed: 4.897523
 // 0(9, 5) 1(10, 4) 2(7, 5) 3(5, 6) 6(4, 6) 7(9, 6) 9(10, 7) 15(6, 5) 17(8, 8) 18(9, 7) 19(7, 5) 20(9, 4) 21(4, 5) 22(6, 6)
 // Value: 103, Weight: 79, Calls: 67108863
+// Utilization: 100.0%
 //
 // *** Branch and Bound ***
 // Elapsed: 0.001524
 // 0(9, 5) 1(10, 4) 2(7, 5) 3(5, 6) 4(4, 5) 6(4, 6) 7(9, 6) 9(10, 7) 15(6, 5) 17(8, 8) 18(9, 7) 19(7, 5) 20(9, 4) 22(6, 6)
 // Value: 103, Weight: 79, Calls: 589017
+// Utilization: 100.0%
 
 func main() {
+	flag.Parse()
+
 	items := makeItems(numItems, minValue, maxValue, minWeight, maxWeight)
-	allowedWeight = sumWeights(items, true) / 2
+	if *capacityFraction < 0 {
+		fmt.Fprintln(os.Stderr, "-capacity-fraction must not be negative")
+		os.Exit(2)
+	}
+	allowedWeight = int(float64(sumWeights(items, true)) * *capacityFraction)
 
 	// Display basic parameters.
 	fmt.Println("*** Parameters ***")
@@ -52,20 +71,45 @@ func main() {
 	fmt.Println()
 
 	// Exhaustive search
+	mismatches := 0
 	if numItems > 25 { // Only run exhaustive search if numItems <= 25.
-		fmt.Println("Too many items for exhaustive search\n")
+		fmt.Println("Too many items for exhaustive search")
+		fmt.Println()
 	} else {
 		fmt.Println("*** Exhaustive Search ***")
-		runAlgorithm(exhaustiveSearch, items, allowedWeight)
+		value := runAlgorithm(exhaustiveSearch, items, allowedWeight)
+		if !checkOptimal("Exhaustive search", value) {
+			mismatches++
+		}
 	}
 
 	// Branch and bound
 	if numItems > 45 { // Only run branch and bound if numItems <= 45.
-		fmt.Println("Too many items for branch and bound\n")
+		fmt.Println("Too many items for branch and bound")
+		fmt.Println()
 	} else {
 		fmt.Println("*** Branch and Bound ***")
-		runAlgorithm(branchAndBound, items, allowedWeight)
+		value := runAlgorithm(branchAndBound, items, allowedWeight)
+		if !checkOptimal("Branch and bound", value) {
+			mismatches++
+		}
+	}
+
+	if mismatches > 0 {
+		os.Exit(1)
+	}
+}
+
+// Check an algorithm's value against -assert-optimal, if it was given.
+// Print the expected and actual values on a mismatch.
+// Return false if they differ.
+func checkOptimal(name string, value int) bool {
+	if *assertOptimal < 0 || value == *assertOptimal {
+		return true
 	}
+	fmt.Fprintf(os.Stderr, "%s: expected optimum %d, actual %d (%+d)\n",
+		name, *assertOptimal, value, value-*assertOptimal)
+	return false
 }
 
 // Make some random items.
@@ -85,6 +129,8 @@ func makeItems(numItems, minValue, maxValue, minWeight, maxWeight int) []Item {
 }
 
 // Return a copy of the items slice.
+// One make and one copy is as fast as copying gets, so calling this less
+// often is the only way to make it cheaper. BenchmarkCopyItems measures it.
 func copyItems(items []Item) []Item {
 	newItems := make([]Item, len(items))
 	copy(newItems, items)
@@ -104,7 +150,8 @@ func sumValues(items []Item, addAll bool) int {
 }
 
 // Run the algorithm. Display the elapsed time and solution.
-func runAlgorithm(alg func([]Item, int) ([]Item, int, int), items []Item, allowedWeight int) {
+// Return the solution's value.
+func runAlgorithm(alg func([]Item, int) ([]Item, int, int), items []Item, allowedWeight int) int {
 	// Copy the items so the run isn't influenced by a previous run.
 	testItems := copyItems(items)
 
@@ -119,7 +166,17 @@ func runAlgorithm(alg func([]Item, int) ([]Item, int, int), items []Item, allowe
 	printSelected(solution)
 	fmt.Printf("Value: %d, Weight: %d, Calls: %d\n",
 		totalValue, sumWeights(solution, false), functionCalls)
+	fmt.Printf("Utilization: %.1f%%\n", utilization(solution, allowedWeight))
 	fmt.Println()
+	return totalValue
+}
+
+// Return the selected items' weight as a percentage of the allowed weight.
+func utilization(items []Item, allowedWeight int) float64 {
+	if allowedWeight <= 0 {
+		return 0
+	}
+	return float64(sumWeights(items, false)) * 100 / float64(allowedWeight)
 }
 
 // Print the selected items.
@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+// The TEST RESULTs comment in main.go documents this instance.
+func TestSeed1337Result(t *testing.T) {
+	items := makeItems(numItems, minValue, maxValue, minWeight, maxWeight)
+	allowedWeight := sumWeights(items, true) / 2
+	if allowedWeight != 79 {
+		t.Fatalf("allowed weight %d, want 79", allowedWeight)
+	}
+
+	solution, value, _ := branchAndBound(copyItems(items), allowedWeight)
+	if value != 103 {
+		t.Errorf("value %d, want 103", value)
+	}
+	if weight := sumWeights(solution, false); weight != 79 {
+		t.Errorf("weight %d, want 79", weight)
+	}
+}
+
+// Copying makes one allocation of the items' size and nothing else, since
+// this Item holds no slices. Compare with the larger Item in
+// cmd/dynamic-programming, whose BenchmarkCopyItems copies more per item.
+func BenchmarkCopyItems(b *testing.B) {
+	items := makeItems(40, minValue, maxValue, minWeight, maxWeight)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		copyItems(items)
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// Return true if two exact algorithms found the same optimal value for the
+// items. If they didn't, one of them has a bug, so write the instance in
+// the form -replay reads and both solutions to w, and return false.
+// Different selections worth the same are both optimal, so they agree.
+// Running the command over many seeds makes this a fuzzer for the solvers.
+func agreeOnValue(w io.Writer, items []Item, allowedWeight int, a, b Solution) bool {
+	if a.Value == b.Value {
+		return true
+	}
+
+	onlyA, onlyB, _ := diffSolutions(a.Items, b.Items)
+	fmt.Fprintf(w, "%s and %s disagree on the optimal value\n", a.Algorithm, b.Algorithm)
+	fmt.Fprintln(w, a)
+	fmt.Fprintf(w, "  selected %v, %d only here: %v\n", a.SelectedIDs(), len(onlyA), onlyA)
+	fmt.Fprintln(w, b)
+	fmt.Fprintf(w, "  selected %v, %d only here: %v\n", b.SelectedIDs(), len(onlyB), onlyB)
+	fmt.Fprintln(w, "Instance, for -replay:")
+	fmt.Fprintf(w, "%s\n", dumpInstance(items, allowedWeight))
+	return false
+}
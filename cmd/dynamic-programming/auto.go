@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"log"
+)
+
+// Thresholds that solveAuto uses to pick an algorithm.
+// Change them to tune the choice for your machine.
+var autoMaxExhaustiveItems = 22     // Use exhaustive search for up to this many items.
+var autoMaxBranchBoundItems = 40    // Use branch and bound for up to this many items
+var autoMaxBranchBoundWeight = 1000 // if the allowed weight is at most this.
+var autoMaxTableCells = 50_000_000  // Use dynamic programming if its table has at most this many cells.
+
+// Pick an algorithm that suits the items and allowed weight, and run it.
+//
+//   - Exhaustive search when there are few enough items to try every subset.
+//   - Branch and bound for a few more items with a small allowed weight.
+//   - Dynamic programming while the numItems * (allowedWeight + 1) table
+//     fits in autoMaxTableCells.
+//   - Greedy otherwise. It is fast but may miss the best solution.
+func solveAuto(items []Item, allowedWeight int) Solution {
+	name, alg, reason := chooseAuto(len(items), allowedWeight)
+	log.Printf("solveAuto: using %s because %s", name, reason)
+	return solve(name, alg, items, allowedWeight)
+}
+
+// Return the name of the algorithm that solveAuto would use,
+// the algorithm, and the reason for the choice.
+func chooseAuto(numItems, allowedWeight int) (string, func([]Item, int) ([]Item, int, int), string) {
+	tableCells := numItems * (allowedWeight + 1)
+
+	switch {
+	case numItems <= autoMaxExhaustiveItems:
+		return "Exhaustive search", exhaustiveSearch,
+			fmt.Sprintf("%d items <= %d", numItems, autoMaxExhaustiveItems)
+	case numItems <= autoMaxBranchBoundItems && allowedWeight <= autoMaxBranchBoundWeight:
+		return "Branch and bound", branchAndBound,
+			fmt.Sprintf("%d items <= %d and allowed weight %d <= %d",
+				numItems, autoMaxBranchBoundItems, allowedWeight, autoMaxBranchBoundWeight)
+	case tableCells <= autoMaxTableCells:
+		return "Dynamic programming", dynamicProgramming,
+			fmt.Sprintf("table of %d cells <= %d", tableCells, autoMaxTableCells)
+	default:
+		return "Greedy", greedy,
+			fmt.Sprintf("table of %d cells > %d, so the result may not be optimal",
+				tableCells, autoMaxTableCells)
+	}
+}
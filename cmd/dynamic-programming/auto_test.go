@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestChooseAutoBands(t *testing.T) {
+	for _, test := range []struct {
+		numItems, allowedWeight int
+		want                    string
+	}{
+		{autoMaxExhaustiveItems, 1_000_000, "Exhaustive search"},
+		{autoMaxExhaustiveItems + 1, autoMaxBranchBoundWeight, "Branch and bound"},
+		{autoMaxBranchBoundItems, autoMaxBranchBoundWeight, "Branch and bound"},
+		{autoMaxBranchBoundItems, autoMaxBranchBoundWeight + 1, "Dynamic programming"},
+		{autoMaxBranchBoundItems + 1, 100, "Dynamic programming"},
+		{1000, autoMaxTableCells/1000 - 1, "Dynamic programming"},
+		{1000, autoMaxTableCells / 1000, "Greedy"},
+	} {
+		if name, _, reason := chooseAuto(test.numItems, test.allowedWeight); name != test.want {
+			t.Errorf("%d items, allowed weight %d: chose %s because %s, want %s",
+				test.numItems, test.allowedWeight, name, reason, test.want)
+		}
+	}
+}
+
+func TestSolveUsesChosenAlgorithm(t *testing.T) {
+	items, allowedWeight := seedInstance(1, 30)
+	solution, proof := Solve(items, allowedWeight)
+	requireFeasible(t, items, allowedWeight, solution)
+	if solution.Algorithm != "Branch and bound" || !proof.Proven {
+		t.Errorf("solved with %s, proven %t, want branch and bound, proven", solution.Algorithm, proof.Proven)
+	}
+}
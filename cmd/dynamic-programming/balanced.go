@@ -0,0 +1,37 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+)
+
+// Make numItems random items whose weights add up to exactly totalWeight,
+// with values from valueDist. With capacity totalWeight/2, packing them is
+// a partition problem: can half the weight be filled exactly?
+//
+// The weights split totalWeight at numItems-1 distinct random points, so
+// every item weighs at least 1. totalWeight must be at least numItems.
+func makeBalancedItems(random *rand.Rand, numItems, totalWeight int, valueDist func(*rand.Rand) int) []Item {
+	if numItems < 1 || totalWeight < numItems {
+		panic("makeBalancedItems: need at least one item and a total weight of at least 1 per item")
+	}
+
+	// Pick the cut points between 1 and totalWeight-1.
+	seen := make(map[int]bool)
+	cuts := []int{0, totalWeight}
+	for len(cuts) < numItems+1 {
+		cut := 1 + random.Intn(totalWeight-1)
+		if !seen[cut] {
+			seen[cut] = true
+			cuts = append(cuts, cut)
+		}
+	}
+	slices.Sort(cuts)
+
+	items := make([]Item, numItems)
+	for i := range items {
+		items[i] = Item{id: i, blockedBy: -1,
+			value: valueDist(random), weight: cuts[i+1] - cuts[i], priority: 1}
+	}
+	return items
+}
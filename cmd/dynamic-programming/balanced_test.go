@@ -0,0 +1,26 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMakeBalancedItemsSumToTotal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ numItems, totalWeight int }{
+		{1, 1}, {1, 50}, {5, 5}, {10, 11}, {20, 1000}, {100, 100_000},
+	} {
+		items := makeBalancedItems(random, test.numItems, test.totalWeight, uniformValues(minValue, maxValue))
+		if len(items) != test.numItems {
+			t.Errorf("%d items of total %d: made %d items", test.numItems, test.totalWeight, len(items))
+		}
+		if total := sumWeights(items, true); total != test.totalWeight {
+			t.Errorf("%d items of total %d: the weights add up to %d", test.numItems, test.totalWeight, total)
+		}
+		for _, item := range items {
+			if item.weight < 1 {
+				t.Errorf("%d items of total %d: item %d weighs %d", test.numItems, test.totalWeight, item.id, item.weight)
+			}
+		}
+	}
+}
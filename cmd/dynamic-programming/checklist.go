@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Write the solution as a packing list: a line with a box to tick for each
+// selected item, by name if it has one and by id if not, then the totals
+// and the capacity left over.
+func writeChecklist(w io.Writer, solution Solution) {
+	// Return the label the list shows for an item.
+	label := func(item Item) string {
+		if item.name != "" {
+			return item.name
+		}
+		return "item " + strconv.Itoa(item.id)
+	}
+
+	width := 0
+	for _, item := range solution.Items {
+		if item.isSelected {
+			width = max(width, len(label(item)))
+		}
+	}
+
+	fmt.Fprintln(w, "Packing list")
+	count := 0
+	for _, item := range solution.Items {
+		if item.isSelected {
+			fmt.Fprintf(w, "[ ] %-*s  value %d, weight %d\n", width, label(item), item.value, item.weight)
+			count++
+		}
+	}
+	weight := sumWeights(solution.Items, false)
+	fmt.Fprintf(w, "Total: value %d, weight %d, packed %d\n", solution.Value, weight, count)
+	fmt.Fprintf(w, "Remaining capacity: %d of %d\n", solution.AllowedWeight-weight, solution.AllowedWeight)
+}
@@ -0,0 +1,18 @@
+package main
+
+import "testing"
+
+// Branch and bound's calls on the Chvátal instances should grow
+// exponentially, so by the same factor over each 4 more items.
+// Nearly doubling with each item, that's well over 8 times.
+func TestChvatalCallsGrowExponentially(t *testing.T) {
+	previous := 0
+	for _, n := range []int{10, 14, 18} {
+		items, allowedWeight := makeChvatalInstance(n)
+		_, calls, _ := countBranchAndBound(items, allowedWeight)
+		if previous > 0 && calls < 8*previous {
+			t.Errorf("%d items took %d calls, less than 8 times the %d for 4 fewer", n, calls, previous)
+		}
+		previous = calls
+	}
+}
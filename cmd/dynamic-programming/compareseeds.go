@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// The algorithms -compare-seeds-csv can run, by the names -algorithms takes.
+var compareAlgorithms = map[string]Algorithm{
+	"exhaustive":     {"Exhaustive search", exhaustiveSearch},
+	"bnb":            {"Branch and bound", branchAndBound},
+	"bnb-loose":      {"Branch and bound, loose bound", branchAndBoundLooseBound},
+	"rods":           {"Rod's technique Sorted", rodsTechniqueSorted},
+	"greedy":         {"Greedy", greedy},
+	"greedy-refined": {"Greedy refined", greedyRefined},
+	"dp":             {"Dynamic programming", dynamicProgramming},
+	"memo":           {"Memoized dynamic programming", memoizedDynamicProgramming},
+}
+
+// Parse a seed range like "1-100", or a single seed like "7".
+// Return the first and last seeds.
+func parseSeedRange(s string) (int64, int64, error) {
+	first, last, found := strings.Cut(s, "-")
+	from, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("bad seed range %q: %w", s, err)
+	}
+	if !found {
+		return from, from, nil
+	}
+	to, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("bad seed range %q: %w", s, err)
+	}
+	if to < from {
+		return 0, 0, fmt.Errorf("bad seed range %q: %d comes after %d", s, from, to)
+	}
+	return from, to, nil
+}
+
+// Return the algorithms named in a comma-separated list, in its order.
+func parseAlgorithms(list string) ([]string, error) {
+	names := strings.Split(list, ",")
+	for _, name := range names {
+		if _, ok := compareAlgorithms[name]; !ok {
+			return nil, fmt.Errorf("unknown algorithm %q", name)
+		}
+	}
+	return names, nil
+}
+
+// What -output-dir writes for each algorithm: the parameters that made the
+// instances, so the file stands alone, and the algorithm's run on each seed.
+type compareFile struct {
+	Algorithm        string       `json:"algorithm"`
+	NumItems         int          `json:"num_items"`
+	CapacityFraction float64      `json:"capacity_fraction"`
+	FirstSeed        int64        `json:"first_seed"`
+	LastSeed         int64        `json:"last_seed"`
+	Runs             []compareRun `json:"runs"`
+}
+
+type compareRun struct {
+	Seed          int64     `json:"seed"`
+	TotalValue    int       `json:"total_value"`
+	TotalWeight   int       `json:"total_weight"`
+	AllowedWeight int       `json:"allowed_weight"`
+	Value         int       `json:"value"`
+	Weight        int       `json:"weight"`
+	Calls         int       `json:"calls"`
+	Elapsed       float64   `json:"elapsed"`
+	Selected      Selection `json:"selected"`
+}
+
+// Write a CSV with one row per seed and each algorithm's value, calls,
+// and elapsed seconds on that seed's instance of numItems items.
+// Every algorithm in a row solves the same instance.
+// If outputDir isn't empty, also write each algorithm's runs to
+// outputDir/<name>.json, creating the directory if needed.
+func writeCompareSeeds(w io.Writer, firstSeed, lastSeed int64, numItems int, names []string, outputDir string) error {
+	// Create the directory first, so a bad one fails before the runs.
+	if outputDir != "" {
+		if err := os.MkdirAll(outputDir, 0o755); err != nil {
+			return fmt.Errorf("creating output directory: %w", err)
+		}
+	}
+	files := make(map[string]*compareFile)
+	for _, name := range names {
+		files[name] = &compareFile{compareAlgorithms[name].Name, numItems, *capacityFraction, firstSeed, lastSeed, []compareRun{}}
+	}
+
+	out := csv.NewWriter(w)
+	header := []string{"seed"}
+	for _, name := range names {
+		header = append(header, name+"_value", name+"_calls", name+"_elapsed")
+	}
+	out.Write(header)
+
+	for seed := firstSeed; seed <= lastSeed; seed++ {
+		items, allowedWeight := seedInstance(seed, numItems)
+		row := []string{strconv.FormatInt(seed, 10)}
+		for _, name := range names {
+			alg := compareAlgorithms[name]
+			stop := measure()
+			solution := solve(alg.Name, alg.Run, items, allowedWeight)
+			cost := stop()
+			row = append(row,
+				strconv.Itoa(solution.Value),
+				strconv.Itoa(solution.Calls),
+				strconv.FormatFloat(cost.elapsed.Seconds(), 'f', 6, 64))
+			files[name].Runs = append(files[name].Runs, compareRun{seed,
+				sumValues(items, true), sumWeights(items, true), allowedWeight,
+				solution.Value, sumWeights(solution.Items, false), solution.Calls,
+				cost.elapsed.Seconds(), selectedIDs(solution.Items)})
+		}
+		out.Write(row)
+	}
+
+	out.Flush()
+	if err := out.Error(); err != nil {
+		return err
+	}
+	if outputDir == "" {
+		return nil
+	}
+	for _, name := range names {
+		data, err := json.MarshalIndent(files[name], "", "  ")
+		if err != nil {
+			// The compare types always marshal.
+			panic(err)
+		}
+		path := filepath.Join(outputDir, name+".json")
+		if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+			return fmt.Errorf("writing %s results: %w", name, err)
+		}
+	}
+	return nil
+}
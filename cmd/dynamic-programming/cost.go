@@ -0,0 +1,29 @@
+package main
+
+import (
+	"runtime"
+	"time"
+)
+
+// What a run cost in time and memory.
+type runCost struct {
+	elapsed    time.Duration
+	allocBytes uint64 // Bytes allocated during the run.
+	numAllocs  uint64 // Heap objects allocated during the run.
+}
+
+// Start measuring a run and return a function that stops measuring
+// and returns the cost. The memory counts are for the whole program,
+// so they include anything other goroutines allocated meanwhile.
+func measure() func() runCost {
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	return func() runCost {
+		elapsed := time.Since(start)
+		var after runtime.MemStats
+		runtime.ReadMemStats(&after)
+		return runCost{elapsed, after.TotalAlloc - before.TotalAlloc, after.Mallocs - before.Mallocs}
+	}
+}
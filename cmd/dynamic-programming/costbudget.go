@@ -0,0 +1,55 @@
+package main
+
+// Use dynamic programming to find the most valuable selection that weighs
+// at most weightCap and costs at most costCap. Unlike a second size
+// measure such as volume, the cost is its own budget, so the table
+// has a dimension for each constraint and needs
+// numItems * (weightCap + 1) * (costCap + 1) cells.
+// Return the selected ids and their total value.
+func costConstrainedKnapsack(items []Item, weightCap, costCap int) (Selection, int) {
+	if weightCap < 0 || costCap < 0 {
+		return Selection{}, 0
+	}
+
+	// bestValue[w][c] is the best value of the items so far
+	// weighing at most w and costing at most c.
+	bestValue := make([][]int, weightCap+1)
+	for w := range bestValue {
+		bestValue[w] = make([]int, costCap+1)
+	}
+
+	// took[i][w][c] is true if item i improved bestValue[w][c].
+	took := make([][][]bool, len(items))
+	for i, item := range items {
+		took[i] = make([][]bool, weightCap+1)
+		for w := range took[i] {
+			took[i][w] = make([]bool, costCap+1)
+		}
+		if item.value <= 0 {
+			continue
+		}
+
+		// Work downwards so item i is only used once.
+		for w := weightCap; w >= item.weight; w-- {
+			for c := costCap; c >= item.cost; c-- {
+				value := bestValue[w-item.weight][c-item.cost] + item.value
+				if value > bestValue[w][c] {
+					bestValue[w][c] = value
+					took[i][w][c] = true
+				}
+			}
+		}
+	}
+
+	// Work backwards to find the items.
+	selection := Selection{}
+	w, c := weightCap, costCap
+	for i := len(items) - 1; i >= 0; i-- {
+		if took[i][w][c] {
+			selection = append(Selection{items[i].id}, selection...)
+			w -= items[i].weight
+			c -= items[i].cost
+		}
+	}
+	return selection, bestValue[weightCap][costCap]
+}
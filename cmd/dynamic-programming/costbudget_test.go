@@ -0,0 +1,24 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCostBudgetBinds(t *testing.T) {
+	// Everything fits by weight, but item 0 costs too much.
+	items := []Item{newItem(0, 10, 2), newItem(1, 8, 2), newItem(2, 7, 2)}
+	items[0].cost = 5
+	items[1].cost = 1
+	items[2].cost = 1
+
+	selection, value := costConstrainedKnapsack(items, 6, 100)
+	if value != 25 || !slices.Equal(selection, Selection{0, 1, 2}) {
+		t.Fatalf("with a loose budget: %v worth %d, want [0 1 2] worth 25", selection, value)
+	}
+
+	selection, value = costConstrainedKnapsack(items, 6, 2)
+	if value != 15 || !slices.Equal(selection, Selection{1, 2}) {
+		t.Errorf("with a budget of 2: %v worth %d, want [1 2] worth 15", selection, value)
+	}
+}
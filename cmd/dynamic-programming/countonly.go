@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Run branch and bound only to count its calls and prunes, for studying
+// the size of the search tree. It makes the same calls as branchAndBound,
+// but doesn't copy the items at each solution or keep the best selection.
+// Return the best value, the number of calls, and the prune counts.
+func countBranchAndBound(items []Item, allowedWeight int) (int, int, pruneCounts) {
+	prunes := pruneCounts{}
+	_, totalValue, functionCalls := withoutNegativeItems(func(items []Item, allowedWeight int) ([]Item, int, int) {
+		search := &bnbSearch{minWeights: suffixMinWeights(items), order: ratioOrder(items),
+			prunes: &prunes, countOnly: true}
+		return searchBranchAndBound(copyItems(items), search, allowedWeight)
+	})(items, allowedWeight)
+	return totalValue, functionCalls, prunes
+}
+
+// Print branch and bound's calls, prunes and elapsed time, but no solution.
+func printCountOnly(items []Item, allowedWeight int) {
+	start := time.Now()
+	totalValue, functionCalls, prunes := countBranchAndBound(items, allowedWeight)
+	elapsed := time.Since(start)
+
+	fmt.Printf("# items: %d, Allowed weight: %d\n", len(items), allowedWeight)
+	fmt.Printf("Elapsed: %f\n", elapsed.Seconds())
+	fmt.Printf("Value: %d, Calls: %d\n", totalValue, functionCalls)
+	fmt.Println(prunes.String())
+}
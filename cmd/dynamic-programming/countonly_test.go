@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestCountOnlyMatchesBranchAndBound(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		items, allowedWeight := seedInstance(seed, 25)
+		// A penalty item checks that both leave it out the same way.
+		items[2].value = -4
+
+		value, calls, prunes := countBranchAndBound(items, allowedWeight)
+
+		pruneStats = &pruneCounts{}
+		full := solve("Branch and bound", branchAndBound, items, allowedWeight)
+		fullPrunes := *pruneStats
+		pruneStats = nil
+
+		if value != full.Value || calls != full.Calls || prunes != fullPrunes {
+			t.Errorf("seed %d: counting found %d in %d calls, %v; branch and bound %d in %d calls, %v",
+				seed, value, calls, prunes.String(), full.Value, full.Calls, fullPrunes.String())
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import "math"
+
+// The objectives -objective can select.
+const (
+	objectiveMaxValue  = "max-value"
+	objectiveMinWeight = "min-weight"
+)
+
+// Use dynamic programming to find the lightest selection worth at least
+// valueFloor, ignoring the capacity. This is the covering form of the
+// knapsack problem: instead of packing the most value into a weight,
+// it covers a value with the least weight, say the fewest kilograms of
+// food that still give a day's calories.
+//
+// Any value of valueFloor or more is as good as valueFloor, so the table
+// tracks the least weight for each value up to the floor and needs
+// numItems * (valueFloor + 1) cells. Items without a positive value
+// can only add weight, so it never takes them.
+// Return the selected ids, their total weight and value,
+// and false if all the items together are worth less than valueFloor.
+func minWeightForValue(items []Item, valueFloor int) (Selection, int, int, bool) {
+	numItems := len(items)
+	valueFloor = max(valueFloor, 0)
+
+	// leastWeight[v] is the least weight of the items so far worth
+	// exactly v, or at least v for v == valueFloor.
+	const unreachable = math.MaxInt
+	leastWeight := make([]int, valueFloor+1)
+	for v := 1; v <= valueFloor; v++ {
+		leastWeight[v] = unreachable
+	}
+
+	// cameFrom[i][v] is the value item i was added to when it improved
+	// leastWeight[v], or -1 if it didn't. Several values can reach the
+	// floor, so unlike took in knapsackWeightRange it needs the value.
+	cameFrom := make([][]int, numItems)
+	for i, item := range items {
+		cameFrom[i] = make([]int, valueFloor+1)
+		for v := range cameFrom[i] {
+			cameFrom[i][v] = -1
+		}
+		if item.value <= 0 {
+			continue
+		}
+
+		// Work downwards so item i is only used once.
+		// Every value within item.value of the floor reaches the floor.
+		for v := valueFloor - 1; v >= 0; v-- {
+			prev := leastWeight[v]
+			if prev == unreachable {
+				continue
+			}
+			to := min(v+item.value, valueFloor)
+			if prev+item.weight < leastWeight[to] {
+				leastWeight[to] = prev + item.weight
+				cameFrom[i][to] = v
+			}
+		}
+	}
+	if leastWeight[valueFloor] == unreachable {
+		return nil, 0, 0, false
+	}
+
+	// Work backwards to find the items.
+	selection := Selection{}
+	value := 0
+	v := valueFloor
+	for i := numItems - 1; i >= 0; i-- {
+		if cameFrom[i][v] >= 0 {
+			selection = append(Selection{items[i].id}, selection...)
+			value += items[i].value
+			v = cameFrom[i][v]
+		}
+	}
+	return selection, leastWeight[valueFloor], value, true
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestObjectivesSelectDifferently(t *testing.T) {
+	items := []Item{newItem(0, 9, 5), newItem(1, 10, 4), newItem(2, 7, 5)}
+
+	// Packing capacity 10 takes the two most valuable items.
+	packed := solve("Dynamic programming", dynamicProgramming, items, 10)
+	requireFeasible(t, items, 10, packed)
+	if got := packed.SelectedIDs(); packed.Value != 19 || !slices.Equal(got, Selection{0, 1}) {
+		t.Errorf("max-value selected %v worth %d, want [0 1] worth 19", got, packed.Value)
+	}
+
+	// Covering a value of 10 needs only item 1.
+	selection, weight, value, ok := minWeightForValue(items, 10)
+	if !ok || weight != 4 || value != 10 || !slices.Equal(selection, Selection{1}) {
+		t.Errorf("min-weight selected %v weighing %d and worth %d (found %t), want [1] weighing 4 and worth 10",
+			selection, weight, value, ok)
+	}
+
+	// All of the items together are worth only 26.
+	if _, _, _, ok := minWeightForValue(items, 27); ok {
+		t.Error("min-weight covered a value of 27")
+	}
+}
+
+func TestMinWeightForValueMatchesBruteForce(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		items, _ := seedInstance(seed, 12)
+		floor := sumValues(items, true) / 3
+
+		// Try every subset for the lightest one worth at least the floor.
+		least := -1
+		for mask := 0; mask < 1<<len(items); mask++ {
+			value, weight := 0, 0
+			for i, item := range items {
+				if mask&(1<<i) != 0 {
+					value += item.value
+					weight += item.weight
+				}
+			}
+			if value >= floor && (least < 0 || weight < least) {
+				least = weight
+			}
+		}
+
+		selection, weight, value, ok := minWeightForValue(items, floor)
+		if !ok || weight != least {
+			t.Errorf("seed %d: found weight %d (%t), want %d", seed, weight, ok, least)
+			continue
+		}
+		gotValue, gotWeight := 0, 0
+		for _, id := range selection {
+			gotValue += items[id].value
+			gotWeight += items[id].weight
+		}
+		if gotValue != value || gotWeight != weight || value < floor {
+			t.Errorf("seed %d: selected %v worth %d and weighing %d, returned %d and %d for floor %d",
+				seed, selection, gotValue, gotWeight, value, weight, floor)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+)
+
+// The value distributions that -value-dist accepts. Most center on the
+// range from minValue to maxValue, but the heavy tail of the exponential
+// one makes a few items much more valuable than the rest. With constant
+// values, the best solution is just the most items that fit.
+var valueDists = map[string]func(*rand.Rand) int{
+	"uniform":     uniformValues(minValue, maxValue),
+	"normal":      normalValues(float64(minValue+maxValue)/2, float64(maxValue-minValue)/4, minValue),
+	"exponential": exponentialValues(float64(minValue+maxValue)/2, minValue),
+	"constant":    constantValues(maxValue),
+}
+
+// Return a distribution of values spread evenly from low to high.
+func uniformValues(low, high int) func(*rand.Rand) int {
+	return func(random *rand.Rand) int {
+		return random.Intn(high-low+1) + low
+	}
+}
+
+// Return a distribution of values that are normally distributed
+// around mean, rounded and raised to at least low.
+func normalValues(mean, stdDev float64, low int) func(*rand.Rand) int {
+	return func(random *rand.Rand) int {
+		return max(int(math.Round(random.NormFloat64()*stdDev+mean)), low)
+	}
+}
+
+// Return a distribution of values that are exponentially distributed
+// with the given mean, rounded and raised to at least low.
+func exponentialValues(mean float64, low int) func(*rand.Rand) int {
+	return func(random *rand.Rand) int {
+		return max(int(math.Round(random.ExpFloat64()*mean)), low)
+	}
+}
+
+// Return a distribution where every value is the same.
+func constantValues(value int) func(*rand.Rand) int {
+	return func(*rand.Rand) int {
+		return value
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestConstantDistributionGivesEqualValues(t *testing.T) {
+	items := makeItemsFrom(rand.New(rand.NewSource(1)), 50, valueDists["constant"], minWeight, maxWeight)
+	for _, item := range items {
+		if item.value != maxValue {
+			t.Fatalf("item %d is worth %d, want every item worth %d", item.id, item.value, maxValue)
+		}
+	}
+}
+
+func TestDistributionsRespectTheLowestValue(t *testing.T) {
+	for name, dist := range valueDists {
+		items := makeItemsFrom(rand.New(rand.NewSource(1)), 1000, dist, minWeight, maxWeight)
+		for _, item := range items {
+			if item.value < minValue {
+				t.Errorf("%s: item %d is worth %d, less than %d", name, item.id, item.value, minValue)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+// Return true if item a dominates item b: it is worth at least as much and
+// weighs no more. Identical items only dominate the ones with larger ids,
+// so two equal items never dominate each other.
+func dominates(a, b Item) bool {
+	if a.value < b.value || a.weight > b.weight {
+		return false
+	}
+	if a.value == b.value && a.weight == b.weight {
+		return a.id < b.id
+	}
+	return true
+}
+
+// Return the ids of the items that no other item dominates, in item order.
+// These are the only items a sensible greedy would start with. Unlike
+// makeBlockLists, which lets identical items block each other, this keeps
+// the first of a set of identical items.
+func efficientItems(items []Item) []int {
+	efficient := []int{}
+	for j := range items {
+		dominated := false
+		for i := range items {
+			if i != j && dominates(items[i], items[j]) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			efficient = append(efficient, items[j].id)
+		}
+	}
+	return efficient
+}
+
+// Remove the items that never need to be in an optimal solution.
+//
+// If an optimal solution contains item j but not an item i that dominates j,
+// swapping j for i gives a solution that is at least as good. So some optimal
+// solution contains every dominator of each selected item, and an item can be
+// dropped when it won't fit together with all of its dominators.
+// (Dropping every dominated item isn't safe: the best packing often holds
+// both an item and the item that dominates it.)
+//
+// Return the remaining items and the ids of the items that were removed.
+func removeDominatedItems(items []Item, allowedWeight int) ([]Item, []int) {
+	reduced := []Item{}
+	removed := []int{}
+	for j := range items {
+		neededWeight := items[j].weight
+		for i := range items {
+			if i != j && dominates(items[i], items[j]) {
+				neededWeight += items[i].weight
+			}
+		}
+
+		if neededWeight > allowedWeight {
+			removed = append(removed, items[j].id)
+		} else {
+			reduced = append(reduced, items[j])
+		}
+	}
+	return reduced, removed
+}
+
+// Wrap an algorithm so it runs without the dominated items.
+// The solution is mapped back onto the original items.
+func withoutDominatedItems(alg func([]Item, int) ([]Item, int, int)) func([]Item, int) ([]Item, int, int) {
+	return func(items []Item, allowedWeight int) ([]Item, int, int) {
+		_, removed := removeDominatedItems(items, allowedWeight)
+		isRemoved := make(map[int]bool)
+		for _, id := range removed {
+			isRemoved[id] = true
+		}
+		notRemoved := func(item Item) bool { return !isRemoved[item.id] }
+		return withItemFilter(notRemoved, alg)(items, allowedWeight)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRemoveDominatedItemsKeepsOptimum(t *testing.T) {
+	numRemoved := 0
+	for seed := int64(1); seed <= 100; seed++ {
+		items, allowedWeight := seedInstance(seed, 15)
+		_, removed := removeDominatedItems(items, allowedWeight)
+		numRemoved += len(removed)
+
+		want := solve("Dynamic programming", dynamicProgramming, items, allowedWeight)
+		for _, alg := range []Algorithm{
+			{"Dynamic programming", dynamicProgramming},
+			{"Branch and bound", branchAndBound},
+			{"Exhaustive search", exhaustiveSearch},
+		} {
+			got := solve(alg.Name, withoutDominatedItems(alg.Run), items, allowedWeight)
+			requireFeasible(t, items, allowedWeight, got)
+			if got.Value != want.Value {
+				t.Errorf("seed %d: %s without dominated items found %d, want %d",
+					seed, alg.Name, got.Value, want.Value)
+			}
+			for _, id := range removed {
+				if got.Items[id].isSelected {
+					t.Errorf("seed %d: %s selected removed item %d", seed, alg.Name, id)
+				}
+			}
+		}
+	}
+	if numRemoved == 0 {
+		t.Error("no seed had a dominated item to remove")
+	}
+}
+
+func TestEfficientItems(t *testing.T) {
+	// Item 1 is dominated by item 0, which is worth more and weighs less.
+	// Item 3 is identical to item 2, so only the first of them is kept.
+	// Item 4 is worth less than item 0 but weighs less, so it stays.
+	items := []Item{newItem(0, 9, 4), newItem(1, 8, 5), newItem(2, 12, 7), newItem(3, 12, 7), newItem(4, 3, 1)}
+	if got := efficientItems(items); !slices.Equal(got, []int{0, 2, 4}) {
+		t.Errorf("got %v, want [0 2 4]", got)
+	}
+}
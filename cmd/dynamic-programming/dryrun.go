@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Estimates above these get a warning from -dry-run.
+var dryRunMaxNodes = 1e9              // Search tree nodes.
+var dryRunMaxBytes = float64(1 << 30) // Table bytes.
+
+// Return the number of nodes in the full search tree for the items.
+// Exhaustive search visits all of them and branch and bound
+// visits them all in the worst case.
+func estimateSearchNodes(numItems int) float64 {
+	return math.Ldexp(1, numItems+1) - 1
+}
+
+// Return the bytes in the dynamic programming tables:
+// two tables of numItems * (allowedWeight + 1) ints.
+func estimateTableBytes(numItems, allowedWeight int) float64 {
+	return float64(numItems) * float64(allowedWeight+1) * 2 * 8
+}
+
+// Print what solving would cost without solving.
+func printDryRun(items []Item, allowedWeight int) {
+	numItems := len(items)
+	nodes := estimateSearchNodes(numItems)
+	tableBytes := estimateTableBytes(numItems, tableWeight(items, allowedWeight))
+
+	fmt.Println("*** Dry run ***")
+	name, _, reason := chooseAuto(numItems, allowedWeight)
+	fmt.Printf("Auto would use: %s because %s\n", name, reason)
+	fmt.Printf("Search tree nodes: %.3g\n", nodes)
+	fmt.Printf("Dynamic programming table: %.3g bytes\n", tableBytes)
+	hardness := estimateHardness(items, allowedWeight)
+	fmt.Printf("Hardness: %.2f\n", hardness)
+	fmt.Printf("Branch and bound calls, roughly: %d\n", estimateBnBNodes(items, allowedWeight))
+
+	if nodes > dryRunMaxNodes {
+		fmt.Printf("Warning: exhaustive search and branch and bound may visit more than %.3g nodes\n", dryRunMaxNodes)
+	}
+	if hardness > hardnessWarning {
+		fmt.Println("Warning: this instance is likely hard for branch and bound")
+	}
+	if tableBytes > dryRunMaxBytes {
+		fmt.Printf("Warning: the dynamic programming table needs more than %.3g bytes\n", dryRunMaxBytes)
+	}
+}
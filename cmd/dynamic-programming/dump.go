@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ppichugin/manning-knapsack-problem/internal/instance"
+)
+
+// The form dumpInstance writes. Unlike the instance.Instance that -json reads,
+// it keeps every Item field, including the search state in the block
+// lists and selections, so a failing search can be replayed exactly.
+type dumpedInstance struct {
+	Capacity int          `json:"capacity"`
+	Items    []dumpedItem `json:"items"`
+}
+
+type dumpedItem struct {
+	ID         int     `json:"id"`
+	BlockedBy  int     `json:"blocked_by"`
+	BlockList  []int   `json:"block_list"`
+	Value      int     `json:"value"`
+	Weight     int     `json:"weight"`
+	IsSelected bool    `json:"is_selected"`
+	Name       string  `json:"name"`
+	Group      int     `json:"group"`
+	Cost       int     `json:"cost"`
+	Priority   float64 `json:"priority"`
+	Requires   []int   `json:"requires"`
+	Value2     int     `json:"value2"`
+}
+
+// Return the items and capacity in a form that loadInstance can read back
+// exactly, for saving an instance that made a solver fail.
+func dumpInstance(items []Item, capacity int) []byte {
+	dumped := dumpedInstance{capacity, make([]dumpedItem, len(items))}
+	for i, item := range items {
+		dumped.Items[i] = dumpedItem{
+			ID:         item.id,
+			BlockedBy:  item.blockedBy,
+			BlockList:  item.blockList,
+			Value:      item.value,
+			Weight:     item.weight,
+			IsSelected: item.isSelected,
+			Name:       item.name,
+			Group:      item.group,
+			Cost:       item.cost,
+			Priority:   item.priority,
+			Requires:   item.requires,
+			Value2:     item.value2,
+		}
+	}
+
+	data, err := json.MarshalIndent(dumped, "", "  ")
+	if err != nil {
+		// The dumped types always marshal.
+		panic(err)
+	}
+	return data
+}
+
+// Read an instance that dumpInstance wrote.
+// Return the items and capacity.
+func loadInstance(data []byte) ([]Item, int, error) {
+	var dumped dumpedInstance
+	if err := json.Unmarshal(data, &dumped); err != nil {
+		return nil, 0, fmt.Errorf("reading dumped instance: %w", err)
+	}
+	if dumped.Capacity < 0 {
+		return nil, 0, fmt.Errorf("%w: %d", instance.ErrNegativeCapacity, dumped.Capacity)
+	}
+	if len(dumped.Items) == 0 {
+		return nil, 0, fmt.Errorf("%w in the dumped instance", instance.ErrEmptyItems)
+	}
+
+	items := make([]Item, len(dumped.Items))
+	for i, item := range dumped.Items {
+		items[i] = Item{
+			id:         item.ID,
+			blockedBy:  item.BlockedBy,
+			blockList:  item.BlockList,
+			value:      item.Value,
+			weight:     item.Weight,
+			isSelected: item.IsSelected,
+			name:       item.Name,
+			group:      item.Group,
+			cost:       item.Cost,
+			priority:   item.Priority,
+			requires:   item.Requires,
+			value2:     item.Value2,
+		}
+	}
+	return items, dumped.Capacity, nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDumpInstanceRoundTrip(t *testing.T) {
+	items := []Item{newItem(0, 9, 5), newItem(1, 10, 4), newItem(2, 7, 5)}
+	items[0].blockList = []int{2}
+	items[1].name = "tent"
+	items[1].group = 3
+	items[1].cost = 40
+	items[1].priority = 1.5
+	items[2].requires = []int{1}
+	items[2].value2 = 6
+	items[2].isSelected = true
+
+	loaded, capacity, err := loadInstance(dumpInstance(items, 12))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if capacity != 12 {
+		t.Errorf("capacity %d, want 12", capacity)
+	}
+	if !reflect.DeepEqual(loaded, items) {
+		t.Errorf("loaded %+v, want %+v", loaded, items)
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBranchAndBoundEpsilonIsWithinEpsilon(t *testing.T) {
+	for seed := int64(1); seed <= 30; seed++ {
+		items, allowedWeight := seedInstance(seed, 30)
+		optimal := solve("Branch and bound", branchAndBound, items, allowedWeight)
+		for _, epsilon := range []float64{0, 0.01, 0.05, 0.2, 0.5} {
+			name := fmt.Sprintf("Branch and bound, epsilon %g", epsilon)
+			solution := solve(name, branchAndBoundEpsilon(epsilon), items, allowedWeight)
+			requireFeasible(t, items, allowedWeight, solution)
+			if float64(solution.Value) < (1-epsilon)*float64(optimal.Value) {
+				t.Errorf("seed %d: %s found %d, less than %g of the optimum %d",
+					seed, name, solution.Value, 1-epsilon, optimal.Value)
+			}
+			if epsilon == 0 && solution.Value != optimal.Value {
+				t.Errorf("seed %d: %s found %d, want the optimum %d", seed, name, solution.Value, optimal.Value)
+			}
+			if solution.Calls > optimal.Calls {
+				t.Errorf("seed %d: %s made %d calls, more than the %d without epsilon",
+					seed, name, solution.Calls, optimal.Calls)
+			}
+		}
+	}
+}
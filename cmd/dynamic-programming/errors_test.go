@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ppichugin/manning-knapsack-problem/internal/instance"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	heavy := []Item{newItem(0, 9, 5), newItem(1, 10, 4), newItem(2, 7, 5)}
+	heavy[0].requires = []int{2}
+	huge := []Item{newItem(0, 1_000_000, 1_000), newItem(1, 1_000_000, 1_000)}
+
+	for _, test := range []struct {
+		name string
+		err  func() error
+		want error
+	}{
+		{"negative JSON capacity", func() error {
+			_, _, err := loadItemsJSON(strings.NewReader(`{"capacity": -1, "items": [{"value": 1, "weight": 1}]}`))
+			return err
+		}, instance.ErrNegativeCapacity},
+		{"negative knapsack capacity", func() error {
+			_, err := parseCapacities("30,-4")
+			return err
+		}, instance.ErrNegativeCapacity},
+		{"no JSON items", func() error {
+			_, _, err := loadItemsJSON(strings.NewReader(`{"capacity": 10, "items": []}`))
+			return err
+		}, instance.ErrEmptyItems},
+		{"no inline items", func() error {
+			_, err := parseItems(" ")
+			return err
+		}, instance.ErrEmptyItems},
+		{"forced item with a heavy prerequisite", func() error {
+			_, err := forcedItems(heavy, 9, Selection{0})
+			return err
+		}, instance.ErrInfeasibleForcedItems},
+		{"max-min table", func() error {
+			_, _, _, _, err := maxMinKnapsack(huge, 2_000)
+			return err
+		}, instance.ErrTableTooLarge},
+	} {
+		if err := test.err(); !errors.Is(err, test.want) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.want)
+		}
+	}
+}
+
+func TestWithForcedItems(t *testing.T) {
+	// Item 0 and its prerequisite, item 2, are forced, leaving weight 3
+	// for the rest. Item 3 requires the forced item 2, which is met.
+	items := []Item{newItem(0, 1, 2), newItem(1, 9, 4), newItem(2, 2, 5), newItem(3, 6, 3)}
+	items[0].requires = []int{2}
+	items[3].requires = []int{2}
+	if _, err := forcedItems(items, 10, Selection{0}); err != nil {
+		t.Fatal(err)
+	}
+
+	solution, value, calls := withForcedItems(Selection{0}, prerequisiteSearch)(deepCopyItems(items), 10)
+	requireFeasible(t, items, 10, Solution{"Forced", solution, value, calls, 10})
+	if got := selectedIDs(solution); value != 9 || !slices.Equal(got, Selection{0, 2, 3}) {
+		t.Errorf("selected %v worth %d, want [0 2 3] worth 9", got, value)
+	}
+}
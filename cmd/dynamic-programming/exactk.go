@@ -0,0 +1,64 @@
+package main
+
+import "math"
+
+// Use dynamic programming to find the most valuable way to pick exactly
+// k items that fit in the knapsack.
+//
+// The table is indexed by item, count, and weight, so it needs
+// numItems * (k + 1) * (allowedWeight + 1) cells.
+// Return the selected ids, their total value, and false if no k items fit.
+func knapsackExactlyK(items []Item, allowedWeight, k int) (Selection, int, bool) {
+	numItems := len(items)
+	if k < 0 || k > numItems || allowedWeight < 0 {
+		return nil, 0, false
+	}
+
+	// bestValue[j][w] is the best value of exactly j items weighing at most w.
+	const unreachable = math.MinInt
+	bestValue := make([][]int, k+1)
+	for j := range bestValue {
+		bestValue[j] = make([]int, allowedWeight+1)
+		if j > 0 {
+			for w := range bestValue[j] {
+				bestValue[j][w] = unreachable
+			}
+		}
+	}
+
+	// took[i][j][w] is true if item i improved bestValue[j][w].
+	took := make([][][]bool, numItems)
+	for i, item := range items {
+		took[i] = make([][]bool, k+1)
+		for j := range took[i] {
+			took[i][j] = make([]bool, allowedWeight+1)
+		}
+
+		// Work downwards so item i is only used once.
+		for j := min(k, i+1); j >= 1; j-- {
+			for w := allowedWeight; w >= item.weight; w-- {
+				prev := bestValue[j-1][w-item.weight]
+				if prev != unreachable && prev+item.value > bestValue[j][w] {
+					bestValue[j][w] = prev + item.value
+					took[i][j][w] = true
+				}
+			}
+		}
+	}
+
+	if bestValue[k][allowedWeight] == unreachable {
+		return nil, 0, false
+	}
+
+	// Work backwards to find the items.
+	selection := Selection{}
+	j, w := k, allowedWeight
+	for i := numItems - 1; i >= 0 && j > 0; i-- {
+		if took[i][j][w] {
+			selection = append(Selection{items[i].id}, selection...)
+			j -= 1
+			w -= items[i].weight
+		}
+	}
+	return selection, bestValue[k][allowedWeight], true
+}
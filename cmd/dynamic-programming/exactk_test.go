@@ -0,0 +1,49 @@
+package main
+
+import (
+	"math/bits"
+	"testing"
+)
+
+func TestKnapsackExactlyKMatchesBruteForce(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		items, allowedWeight := seedInstance(seed, 12)
+		for k := 0; k <= len(items); k++ {
+			// Try every subset of k items for the most valuable one that fits.
+			best, found := 0, false
+			for mask := 0; mask < 1<<len(items); mask++ {
+				if bits.OnesCount(uint(mask)) != k {
+					continue
+				}
+				value, weight := 0, 0
+				for i, item := range items {
+					if mask&(1<<i) != 0 {
+						value += item.value
+						weight += item.weight
+					}
+				}
+				if weight <= allowedWeight && (!found || value > best) {
+					best, found = value, true
+				}
+			}
+
+			selection, value, ok := knapsackExactlyK(items, allowedWeight, k)
+			if ok != found || value != best {
+				t.Errorf("seed %d, k %d: got value %d, found %t, want %d, %t", seed, k, value, ok, best, found)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			gotValue, gotWeight := 0, 0
+			for _, id := range selection {
+				gotValue += items[id].value
+				gotWeight += items[id].weight
+			}
+			if len(selection) != k || gotValue != value || gotWeight > allowedWeight {
+				t.Errorf("seed %d, k %d: selected %v worth %d and weighing %d, want %d items worth %d within %d",
+					seed, k, selection, gotValue, gotWeight, k, value, allowedWeight)
+			}
+		}
+	}
+}
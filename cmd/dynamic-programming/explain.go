@@ -0,0 +1,28 @@
+package main
+
+import "fmt"
+
+// The most reconstruction steps that -explain prints.
+const maxExplainLines = 40
+
+// Narrate how dynamic programming works backwards through prevWeight
+// to recover its solution, printing at most maxLines steps.
+// The items aren't changed.
+func explainReconstruction(items []Item, allowedWeight int, prevWeight [][]int, maxLines int) {
+	w := allowedWeight
+	for i := len(items) - 1; i >= 0; i-- {
+		if len(items)-1-i == maxLines {
+			fmt.Printf("... (%d more items)\n", i+1)
+			return
+		}
+
+		prevW := prevWeight[i][w]
+		if !addedItem(items[i], prevW, w) {
+			fmt.Printf("At item %d, weight %d: prevWeight=%d so item skipped.\n", i, w, prevW)
+		} else {
+			fmt.Printf("At item %d, weight %d: prevWeight=%d so item added (weight -%d).\n",
+				i, w, prevW, items[i].weight)
+			w = prevW
+		}
+	}
+}
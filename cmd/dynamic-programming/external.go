@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Run a solver written in any language as a separate program, so its
+// results can be timed and checked next to the ones here.
+//
+// The protocol:
+//
+//   - The program's standard input is the instance, in the form -json reads:
+//     {"capacity": 62, "items": [{"value": 9, "weight": 5}, ...]}.
+//     An item's id is its position in the list.
+//   - The program writes the ids of the items it selects to standard
+//     output, in the form -warm-start reads: {"selected": [0, 3, 7]}.
+//   - It exits with status 0. Anything it writes to standard error is
+//     included in the error if it fails.
+//
+// The selection is checked like a warm start, and the value is worked out
+// here rather than trusted. If ctx is done first, the program is killed.
+// Return the solution, with no Calls, or an error.
+func runExternalSolver(ctx context.Context, path string, items []Item, allowedWeight int) (Solution, error) {
+	var stdin, stdout, stderr bytes.Buffer
+	if err := saveItemsJSON(&stdin, items, allowedWeight); err != nil {
+		return Solution{}, err
+	}
+
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = &stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Don't wait long for output from anything the program started
+	// after it has been killed.
+	cmd.WaitDelay = time.Second
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return Solution{}, fmt.Errorf("%s: %w", path, ctx.Err())
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return Solution{}, fmt.Errorf("%s: %w: %s", path, err, message)
+		}
+		return Solution{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	selection, err := loadSelection(&stdout)
+	if err != nil {
+		return Solution{}, fmt.Errorf("%s: %w", path, err)
+	}
+	value, _, err := checkSelection(items, allowedWeight, selection)
+	if err != nil {
+		return Solution{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	solution := copyItems(items)
+	selected := make(map[int]bool)
+	for _, id := range selection {
+		selected[id] = true
+	}
+	for i := range solution {
+		solution[i].isSelected = selected[solution[i].id]
+	}
+	return Solution{"External " + path, solution, value, 0, allowedWeight}, nil
+}
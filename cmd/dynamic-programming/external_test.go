@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Setting this makes the test binary act as an external solver instead of
+// running the tests, so runExternalSolver can run it as a real program.
+const fakeSolverEnv = "KNAPSACK_FAKE_SOLVER"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(fakeSolverEnv) {
+	case "":
+		os.Exit(m.Run())
+	case "dp":
+		// Read the instance and echo back what dynamic programming selects.
+		items, allowedWeight, err := loadItemsJSON(os.Stdin)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		solution := solve("Dynamic programming", dynamicProgramming, items, allowedWeight)
+		json.NewEncoder(os.Stdout).Encode(jsonSelection{selectedIDs(solution.Items)})
+		os.Exit(0)
+	case "fail":
+		fmt.Fprintln(os.Stderr, "no solution today")
+		os.Exit(3)
+	case "hang":
+		time.Sleep(time.Minute)
+		os.Exit(0)
+	}
+}
+
+func TestExternalSolverRoundTrip(t *testing.T) {
+	t.Setenv(fakeSolverEnv, "dp")
+	items, allowedWeight := seedInstance(11, 20)
+
+	external, err := runExternalSolver(context.Background(), os.Args[0], items, allowedWeight)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireFeasible(t, items, allowedWeight, external)
+	native := solve("Dynamic programming", dynamicProgramming, items, allowedWeight)
+	if !external.Equal(native) {
+		t.Errorf("the external solver selected %v worth %d, dynamic programming %v worth %d",
+			external.SelectedIDs(), external.Value, native.SelectedIDs(), native.Value)
+	}
+}
+
+func TestExternalSolverFailure(t *testing.T) {
+	t.Setenv(fakeSolverEnv, "fail")
+	items, allowedWeight := seedInstance(11, 5)
+
+	_, err := runExternalSolver(context.Background(), os.Args[0], items, allowedWeight)
+	if err == nil || !strings.Contains(err.Error(), "no solution today") {
+		t.Errorf("got error %v, want one with the solver's standard error", err)
+	}
+}
+
+func TestExternalSolverTimeout(t *testing.T) {
+	t.Setenv(fakeSolverEnv, "hang")
+	items, allowedWeight := seedInstance(11, 5)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := runExternalSolver(ctx, os.Args[0], items, allowedWeight)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want the deadline to be exceeded", err)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// The algorithm families -run can add to the default run,
+// which is Rod's technique and dynamic programming.
+var runFamilies = []string{"exhaustive", "bnb", "greedy", "memo", "dominance", "auto"}
+
+// Return the families named in a comma-separated list, or all of them
+// for "all". An empty list names none.
+func parseRunFamilies(list string) (map[string]bool, error) {
+	families := make(map[string]bool)
+	if list == "" {
+		return families, nil
+	}
+	for _, name := range strings.Split(list, ",") {
+		switch {
+		case name == "all":
+			for _, family := range runFamilies {
+				families[family] = true
+			}
+		case slices.Contains(runFamilies, name):
+			families[name] = true
+		default:
+			return nil, fmt.Errorf("unknown -run family %q, want one of %v or all", name, runFamilies)
+		}
+	}
+	return families, nil
+}
@@ -0,0 +1,107 @@
+package main
+
+// Split the items into the ones keep accepts and the ids of the rest.
+// An item can't be selected without its prerequisites, so an item that
+// requires a dropped item is dropped too.
+// Return the kept items, the dropped ids, and the position in items
+// of each kept item.
+func filterItems(items []Item, keep func(Item) bool) ([]Item, []int, []int) {
+	dropped := make([]bool, len(items))
+	for i, item := range items {
+		dropped[i] = !keep(item)
+	}
+	for changed := true; changed; {
+		changed = false
+		for i, item := range items {
+			for _, r := range item.requires {
+				if !dropped[i] && dropped[r] {
+					dropped[i] = true
+					changed = true
+				}
+			}
+		}
+	}
+
+	kept := []Item{}
+	droppedIDs := []int{}
+	positions := []int{}
+	for i, item := range items {
+		if dropped[i] {
+			droppedIDs = append(droppedIDs, item.id)
+		} else {
+			kept = append(kept, item)
+			positions = append(positions, i)
+		}
+	}
+	return kept, droppedIDs, positions
+}
+
+// Renumber the kept items so ids match their positions, and point their
+// prerequisites at the new positions. The originals aren't changed.
+func renumberKept(kept []Item, positions []int, numItems int) {
+	newPosition := make([]int, numItems)
+	for k, p := range positions {
+		newPosition[p] = k
+	}
+	for k := range kept {
+		kept[k].id = k
+		if kept[k].requires != nil {
+			requires := make([]int, len(kept[k].requires))
+			for j, r := range kept[k].requires {
+				requires[j] = newPosition[r]
+			}
+			kept[k].requires = requires
+		}
+	}
+}
+
+// Return a copy of the items with the items that a solution to the
+// renumbered kept items selected marked as selected. The solution's ids
+// are positions in the kept items, so this works however the algorithm
+// ordered them, and identical items can't be mixed up.
+func restoreSelection(items, solution []Item, positions []int) []Item {
+	result := copyItems(items)
+	for i := range result {
+		result[i].isSelected = false
+	}
+	for _, item := range solution {
+		if item.isSelected {
+			result[positions[item.id]].isSelected = true
+		}
+	}
+	return result
+}
+
+// Wrap an algorithm so it only sees the items keep accepts, for example
+// only the items with a good enough value/weight ratio. The dropped items
+// are never selected, so if keep drops an item the best solution needs,
+// the result isn't optimal.
+// The solution is mapped back onto the original items.
+func withItemFilter(keep func(Item) bool, alg func([]Item, int) ([]Item, int, int)) func([]Item, int) ([]Item, int, int) {
+	return func(items []Item, allowedWeight int) ([]Item, int, int) {
+		kept, droppedIDs, positions := filterItems(items, keep)
+		countExcluded(len(droppedIDs))
+		if len(droppedIDs) == 0 {
+			return alg(items, allowedWeight)
+		}
+		if len(kept) == 0 {
+			return restoreSelection(items, nil, nil), 0, 0
+		}
+
+		renumberKept(kept, positions, len(items))
+		solution, totalValue, functionCalls := alg(kept, allowedWeight)
+		return restoreSelection(items, solution, positions), totalValue, functionCalls
+	}
+}
+
+// Wrap an algorithm so it runs without the items heavier than the allowed
+// weight. They can never be selected, so this is always safe, and the
+// searches no longer branch on them. Each one removed halves exhaustive
+// search's calls, while branch and bound, which already prunes them when
+// they don't fit, saves less.
+func withoutOverweightItems(alg func([]Item, int) ([]Item, int, int)) func([]Item, int) ([]Item, int, int) {
+	return func(items []Item, allowedWeight int) ([]Item, int, int) {
+		fits := func(item Item) bool { return item.weight <= allowedWeight }
+		return withItemFilter(fits, alg)(items, allowedWeight)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWithItemFilterKeepsTwinsApart(t *testing.T) {
+	// Items 0 and 1 look the same but are in different groups.
+	items := []Item{newItem(0, 5, 5), newItem(1, 5, 5), newItem(2, 4, 4), newItem(3, 1, 100)}
+	items[0].group = 1
+	items[1].group = 2
+	items[2].group = 1
+
+	solution := solve("Multiple-choice knapsack", multipleChoiceKnapsack, items, 9)
+	requireFeasible(t, items, 9, solution)
+	if got, want := solution.SelectedIDs(), (Selection{1, 2}); !slices.Equal(got, want) {
+		t.Errorf("selected %v, want %v", got, want)
+	}
+	if solution.Value != 9 {
+		t.Errorf("value %d, want 9", solution.Value)
+	}
+}
+
+func TestFilterItemsDropsDependents(t *testing.T) {
+	items := []Item{newItem(0, 3, 2), newItem(1, -1, 1), newItem(2, 4, 2), newItem(3, 2, 2)}
+	items[2].requires = []int{1}
+	items[3].requires = []int{0}
+
+	kept, droppedIDs, positions := filterItems(items, nonNegativeValue)
+	if !slices.Equal(droppedIDs, []int{1, 2}) {
+		t.Errorf("dropped %v, want [1 2]", droppedIDs)
+	}
+	if !slices.Equal(positions, []int{0, 3}) {
+		t.Errorf("positions %v, want [0 3]", positions)
+	}
+
+	renumberKept(kept, positions, len(items))
+	if kept[1].id != 1 || !slices.Equal(kept[1].requires, []int{0}) {
+		t.Errorf("kept item 1 has id %d and requires %v, want 1 and [0]", kept[1].id, kept[1].requires)
+	}
+	if !slices.Equal(items[3].requires, []int{0}) || items[3].id != 3 {
+		t.Errorf("renumbering changed the original item: %+v", items[3])
+	}
+}
+
+func TestWithoutOverweightItemsKeepsOptimum(t *testing.T) {
+	for seed := int64(1); seed <= 50; seed++ {
+		items, allowedWeight := seedInstance(seed, 15)
+		for i := 0; i < len(items); i += 4 {
+			items[i].weight = allowedWeight + 1
+		}
+
+		_, want, wantCalls := withoutNegativeItems(exhaustiveSearch)(deepCopyItems(items), allowedWeight)
+		_, got, gotCalls := withoutOverweightItems(exhaustiveSearch)(deepCopyItems(items), allowedWeight)
+		if got != want {
+			t.Errorf("seed %d: value %d without overweight items, want %d", seed, got, want)
+		}
+		if gotCalls >= wantCalls {
+			t.Errorf("seed %d: %d calls without overweight items, want fewer than %d", seed, gotCalls, wantCalls)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ppichugin/manning-knapsack-problem/internal/instance"
+)
+
+// Parse a comma-separated list of item ids like "0,3,7".
+func parseIDs(list string) (Selection, error) {
+	ids := Selection{}
+	for _, field := range strings.Split(list, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("bad item id %q: %w", field, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Return which items a solution must select: the forced ones and,
+// transitively, their prerequisites. Return an error if an id doesn't
+// exist, or wrapping ErrInfeasibleForcedItems if they don't fit.
+func forcedItems(items []Item, allowedWeight int, forced Selection) ([]bool, error) {
+	isForced := make([]bool, len(items))
+	pending := append(Selection{}, forced...)
+	for len(pending) > 0 {
+		id := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if id < 0 || id >= len(items) {
+			return nil, fmt.Errorf("forced item %d doesn't exist", id)
+		}
+		if !isForced[id] {
+			isForced[id] = true
+			pending = append(pending, items[id].requires...)
+		}
+	}
+
+	weight := 0
+	for i, item := range items {
+		if isForced[i] {
+			weight += item.weight
+		}
+	}
+	if weight > allowedWeight {
+		return nil, fmt.Errorf("%w: they weigh %d with their prerequisites, more than the allowed weight %d",
+			instance.ErrInfeasibleForcedItems, weight, allowedWeight)
+	}
+	return isForced, nil
+}
+
+// Return an algorithm that selects the forced items and their
+// prerequisites, and runs alg on the rest with the weight that's left.
+// forcedItems must have accepted the forced ids for these items.
+// The rest are renumbered, and their prerequisites among the forced
+// items dropped, since those are met.
+func withForcedItems(forced Selection, alg func([]Item, int) ([]Item, int, int)) func([]Item, int) ([]Item, int, int) {
+	return func(items []Item, allowedWeight int) ([]Item, int, int) {
+		isForced, err := forcedItems(items, allowedWeight, forced)
+		if err != nil {
+			// The caller checked them first.
+			panic(err)
+		}
+
+		rest := []Item{}
+		positions := []int{}
+		value, weight := 0, 0
+		for i, item := range items {
+			if isForced[i] {
+				value += item.value
+				weight += item.weight
+			} else {
+				rest = append(rest, item)
+				positions = append(positions, i)
+			}
+		}
+		newPosition := make([]int, len(items))
+		for k, p := range positions {
+			newPosition[p] = k
+		}
+		for k := range rest {
+			rest[k].id = k
+			requires := []int{}
+			for _, r := range rest[k].requires {
+				if !isForced[r] {
+					requires = append(requires, newPosition[r])
+				}
+			}
+			rest[k].requires = requires
+		}
+
+		solution, restValue, calls := alg(rest, allowedWeight-weight)
+		result := restoreSelection(items, solution, positions)
+		for i := range result {
+			if isForced[i] {
+				result[i].isSelected = true
+			}
+		}
+		return result, value + restValue, calls
+	}
+}
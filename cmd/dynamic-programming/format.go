@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// A formatter writes the results of a run in one output format.
+type formatter interface {
+	parameters(items []Item, allowedWeight int) // Describe the instance.
+	result(solution Solution, cost runCost)
+	note(message string) // Report something that isn't a solution.
+	flush() error        // Write anything still buffered.
+}
+
+// The names that -format accepts.
+var formatNames = []string{"table", "csv", "json"}
+
+// Return the formatter for the named format, writing to w.
+func newFormatter(name string, w io.Writer) (formatter, error) {
+	switch name {
+	case "table":
+		return &tableFormatter{w}, nil
+	case "csv":
+		return &csvFormatter{csv.NewWriter(w), false}, nil
+	case "json":
+		return &jsonFormatter{w: w}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q, want one of %v", name, formatNames)
+}
+
+// The human-readable format, one block per algorithm.
+// It honors -top and -verbose.
+type tableFormatter struct {
+	w io.Writer
+}
+
+func (f *tableFormatter) parameters(items []Item, allowedWeight int) {
+	fmt.Fprintln(f.w, "*** Parameters ***")
+	fmt.Fprintf(f.w, "# items: %d\n", len(items))
+	fmt.Fprintf(f.w, "Total value: %d\n", sumValues(items, true))
+	fmt.Fprintf(f.w, "Total weight: %d\n", sumWeights(items, true))
+	fmt.Fprintf(f.w, "Allowed weight: %d\n", allowedWeight)
+	if *verbose {
+		// The dominance check takes O(n²) time, so only do it when asked.
+		_, removed := removeDominatedItems(items, allowedWeight)
+		fmt.Fprintf(f.w, "Dominated items: %d\n", len(removed))
+		lpValue, _ := lpRelaxation(items, allowedWeight)
+		fmt.Fprintf(f.w, "LP bound: %.2f\n", lpValue)
+		lower, upper := quickBounds(items, allowedWeight)
+		fmt.Fprintf(f.w, "Quick bounds: [%d, %d]\n", lower, upper)
+	}
+	fmt.Fprintln(f.w)
+}
+
+func (f *tableFormatter) result(solution Solution, cost runCost) {
+	fmt.Fprintf(f.w, "*** %s ***\n", solution.Algorithm)
+	fmt.Fprintf(f.w, "Elapsed: %f\n", cost.elapsed.Seconds())
+	fmt.Fprintf(f.w, "Allocated: %d bytes in %d allocations\n", cost.allocBytes, cost.numAllocs)
+	if *topItems > 0 {
+		printTopSelected(f.w, solution.Items, *topItems)
+	} else {
+		printSelectedByID(f.w, solution.Items)
+	}
+	fmt.Fprintf(f.w, "Value: %d, Weight: %d, Calls: %d\n",
+		solution.Value, sumWeights(solution.Items, false), solution.Calls)
+	fmt.Fprintf(f.w, "Utilization: %.1f%%\n", utilization(solution.Items, solution.AllowedWeight))
+	if *verbose {
+		printLeftovers(f.w, solution.Items, solution.AllowedWeight)
+	}
+	fmt.Fprintln(f.w)
+}
+
+func (f *tableFormatter) note(message string) {
+	fmt.Fprintln(f.w, message)
+	fmt.Fprintln(f.w)
+}
+
+func (f *tableFormatter) flush() error { return nil }
+
+// One CSV row per algorithm. Notes aren't written.
+type csvFormatter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func (f *csvFormatter) parameters(items []Item, allowedWeight int) {}
+
+func (f *csvFormatter) result(solution Solution, cost runCost) {
+	if !f.wroteHeader {
+		f.w.Write([]string{"algorithm", "value", "weight", "calls", "utilization", "elapsed",
+			"alloc_bytes", "num_allocs"})
+		f.wroteHeader = true
+	}
+	f.w.Write([]string{
+		solution.Algorithm,
+		strconv.Itoa(solution.Value),
+		strconv.Itoa(sumWeights(solution.Items, false)),
+		strconv.Itoa(solution.Calls),
+		strconv.FormatFloat(utilization(solution.Items, solution.AllowedWeight), 'f', 1, 64),
+		strconv.FormatFloat(cost.elapsed.Seconds(), 'f', 6, 64),
+		strconv.FormatUint(cost.allocBytes, 10),
+		strconv.FormatUint(cost.numAllocs, 10),
+	})
+}
+
+func (f *csvFormatter) note(message string) {}
+
+func (f *csvFormatter) flush() error {
+	f.w.Flush()
+	return f.w.Error()
+}
+
+// A single JSON document written when the run is done.
+type jsonFormatter struct {
+	w      io.Writer
+	report jsonReport
+}
+
+type jsonReport struct {
+	NumItems      int          `json:"num_items"`
+	TotalValue    int          `json:"total_value"`
+	TotalWeight   int          `json:"total_weight"`
+	AllowedWeight int          `json:"allowed_weight"`
+	Results       []jsonResult `json:"results"`
+	Notes         []string     `json:"notes,omitempty"`
+}
+
+type jsonResult struct {
+	Algorithm   string    `json:"algorithm"`
+	Value       int       `json:"value"`
+	Weight      int       `json:"weight"`
+	Calls       int       `json:"calls"`
+	Utilization float64   `json:"utilization"`
+	Elapsed     float64   `json:"elapsed"`
+	AllocBytes  uint64    `json:"alloc_bytes"`
+	NumAllocs   uint64    `json:"num_allocs"`
+	Selected    Selection `json:"selected"`
+}
+
+func (f *jsonFormatter) parameters(items []Item, allowedWeight int) {
+	f.report.NumItems = len(items)
+	f.report.TotalValue = sumValues(items, true)
+	f.report.TotalWeight = sumWeights(items, true)
+	f.report.AllowedWeight = allowedWeight
+}
+
+func (f *jsonFormatter) result(solution Solution, cost runCost) {
+	f.report.Results = append(f.report.Results, jsonResult{
+		solution.Algorithm,
+		solution.Value,
+		sumWeights(solution.Items, false),
+		solution.Calls,
+		utilization(solution.Items, solution.AllowedWeight),
+		cost.elapsed.Seconds(),
+		cost.allocBytes,
+		cost.numAllocs,
+		selectedIDs(solution.Items),
+	})
+}
+
+func (f *jsonFormatter) note(message string) {
+	f.report.Notes = append(f.report.Notes, message)
+}
+
+func (f *jsonFormatter) flush() error {
+	encoder := json.NewEncoder(f.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(f.report)
+}
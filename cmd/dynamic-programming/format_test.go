@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Return the output of each format for the dynamic programming solution
+// to a small instance, and the solution.
+func formatKnownInstance(t *testing.T, name string) (string, Solution) {
+	t.Helper()
+	items := []Item{newItem(0, 9, 5), newItem(1, 10, 4), newItem(2, 7, 5)}
+	solution := solve("Dynamic programming", dynamicProgramming, items, 9)
+	requireFeasible(t, items, 9, solution)
+
+	var b bytes.Buffer
+	f, err := newFormatter(name, &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.parameters(items, 9)
+	f.result(solution, runCost{})
+	if err := f.flush(); err != nil {
+		t.Fatal(err)
+	}
+	return b.String(), solution
+}
+
+func TestTableFormat(t *testing.T) {
+	out, solution := formatKnownInstance(t, "table")
+	want := fmt.Sprintf("Value: 19, Weight: 9, Calls: %d\n", solution.Calls)
+	if !strings.Contains(out, want) {
+		t.Errorf("table output %q doesn't contain %q", out, want)
+	}
+}
+
+func TestCSVFormat(t *testing.T) {
+	out, solution := formatKnownInstance(t, "csv")
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want a header and one row", len(records))
+	}
+	row := records[1]
+	if row[1] != "19" || row[2] != "9" || row[3] != strconv.Itoa(solution.Calls) {
+		t.Errorf("row %v, want value 19, weight 9, calls %d", row, solution.Calls)
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	out, solution := formatKnownInstance(t, "json")
+	var report jsonReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Results) != 1 {
+		t.Fatalf("got %d results, want 1", len(report.Results))
+	}
+	result := report.Results[0]
+	if result.Value != 19 || result.Weight != 9 || result.Calls != solution.Calls {
+		t.Errorf("result %+v, want value 19, weight 9, calls %d", result, solution.Calls)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// The epsilons that the FPTAS frontier tries.
+var frontierEpsilons = []float64{0.5, 0.2, 0.1, 0.05}
+
+// Use the fully polynomial-time approximation scheme to find a solution
+// worth at least (1 - epsilon) times the best possible value.
+//
+// Values are divided by K = epsilon * maxValue / numItems and rounded down,
+// then a dynamic program finds the lightest way to reach each scaled value.
+// Smaller epsilons give better solutions and bigger tables.
+// Return the assignment, value of that assignment,
+// and the number of cells in the table.
+func fptas(items []Item, allowedWeight int, epsilon float64) ([]Item, int, int) {
+	numItems := len(items)
+
+	// Find the largest value of an item that fits on its own.
+	maxValue := 0
+	for _, item := range items {
+		if item.weight <= allowedWeight && item.value > maxValue {
+			maxValue = item.value
+		}
+	}
+	if maxValue == 0 {
+		return items, 0, 0
+	}
+
+	// Scale the values. K < 1 would only make the table bigger.
+	k := epsilon * float64(maxValue) / float64(numItems)
+	if k < 1 {
+		k = 1
+	}
+	scaled := make([]int, numItems)
+	totalScaled := 0
+	for i, item := range items {
+		scaled[i] = int(float64(item.value) / k)
+		totalScaled += scaled[i]
+	}
+
+	// minWeight[i][v] is the least weight that reaches scaled value v
+	// using items 0 through i-1, or -1 if v can't be reached.
+	minWeight := make([][]int, numItems+1)
+	for i := range minWeight {
+		minWeight[i] = make([]int, totalScaled+1)
+		for v := 1; v <= totalScaled; v++ {
+			minWeight[i][v] = -1
+		}
+	}
+	for i := 1; i <= numItems; i++ {
+		for v := 0; v <= totalScaled; v++ {
+			best := minWeight[i-1][v]
+			if scaled[i-1] <= v {
+				prev := minWeight[i-1][v-scaled[i-1]]
+				if prev >= 0 && (best < 0 || prev+items[i-1].weight < best) {
+					best = prev + items[i-1].weight
+				}
+			}
+			minWeight[i][v] = best
+		}
+	}
+
+	// Find the largest scaled value that fits.
+	bestScaled := 0
+	for v := totalScaled; v > 0; v-- {
+		if minWeight[numItems][v] >= 0 && minWeight[numItems][v] <= allowedWeight {
+			bestScaled = v
+			break
+		}
+	}
+
+	// Work backwards to find the items that reach it.
+	v := bestScaled
+	for i := numItems; i > 0; i-- {
+		if minWeight[i][v] != minWeight[i-1][v] {
+			items[i-1].isSelected = true
+			v -= scaled[i-1]
+		}
+	}
+
+	return items, sumValues(items, false), (numItems + 1) * (totalScaled + 1)
+}
+
+// Run the FPTAS for each of the epsilons and print a CSV line for each,
+// comparing its value to the dynamic programming optimum.
+func printFptasFrontier(items []Item, allowedWeight int, epsilons []float64) {
+	_, bestValue, _ := dynamicProgramming(copyItems(items), allowedWeight)
+
+	fmt.Println("epsilon,value,ratio,elapsed,table_size")
+	for _, epsilon := range epsilons {
+		start := time.Now()
+		_, value, tableSize := withoutNegativeItems(func(items []Item, allowedWeight int) ([]Item, int, int) {
+			return fptas(items, allowedWeight, epsilon)
+		})(copyItems(items), allowedWeight)
+		elapsed := time.Since(start)
+
+		ratio := 1.0
+		if bestValue > 0 {
+			ratio = float64(value) / float64(bestValue)
+		}
+		fmt.Printf("%g,%d,%f,%f,%d\n", epsilon, value, ratio, elapsed.Seconds(), tableSize)
+	}
+}
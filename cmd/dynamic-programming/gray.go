@@ -0,0 +1,83 @@
+package main
+
+import "math/bits"
+
+// The most items exhaustiveSearchGray enumerates itself, so subsets fit in
+// a uint64 mask. Far fewer are practical.
+const maxGrayItems = 62
+
+// Try every subset of the items like exhaustiveSearch, but visit them in
+// Gray code order. Each subset differs from the one before in one item,
+// so the running value and weight change by one item's instead of being
+// summed from scratch, and nothing is copied until a subset wins.
+// Ties are broken as exhaustiveSearch breaks them.
+// BenchmarkExhaustiveSearchGray compares the two.
+//
+// With more than maxGrayItems items, this falls back to exhaustiveSearch.
+// Return the best assignment, value of that assignment,
+// and the number of subsets we tried.
+func exhaustiveSearchGray(items []Item, allowedWeight int) ([]Item, int, int) {
+	return doExhaustiveSearchGray(items, allowedWeight, preferCanonical)
+}
+
+// Return a Gray code exhaustive search that breaks value ties by the preference.
+func exhaustiveSearchGrayPreferring(prefer tiePreference) func([]Item, int) ([]Item, int, int) {
+	return func(items []Item, allowedWeight int) ([]Item, int, int) {
+		return doExhaustiveSearchGray(items, allowedWeight, prefer)
+	}
+}
+
+func doExhaustiveSearchGray(items []Item, allowedWeight int, prefer tiePreference) ([]Item, int, int) {
+	numItems := len(items)
+	if numItems > maxGrayItems {
+		return doExhaustiveSearch(items, allowedWeight, 0, prefer)
+	}
+
+	// The empty subset always fits.
+	var mask, bestMask uint64
+	value, weight, bestValue := 0, 0, 0
+	numSubsets := uint64(1) << numItems
+	for k := uint64(1); k < numSubsets; k++ {
+		// Gray code k flips the bit of k's lowest set bit.
+		i := bits.TrailingZeros64(k)
+		mask ^= 1 << i
+		if mask&(1<<i) != 0 {
+			value += items[i].value
+			weight += items[i].weight
+		} else {
+			value -= items[i].value
+			weight -= items[i].weight
+		}
+
+		if weight > allowedWeight || value < bestValue {
+			continue
+		}
+		if value == bestValue {
+			// Ties are rare enough to afford building both solutions.
+			a := candidate{maskItems(items, bestMask), bestValue, 0}
+			b := candidate{maskItems(items, mask), value, 0}
+			if !sameSelection(betterSolutionBy(a, b, prefer).items, b.items) {
+				continue
+			}
+		}
+		bestMask, bestValue = mask, value
+	}
+
+	result := maskItems(items, bestMask)
+	return result, bestValue, int(numSubsets)
+}
+
+// Return a copy of the items with the ones in the mask selected.
+func maskItems(items []Item, mask uint64) []Item {
+	result := copyItems(items)
+	for i := range result {
+		result[i].isSelected = mask&(1<<i) != 0
+	}
+	return result
+}
+
+// Return true if the two solutions select the same items.
+func sameSelection(a, b []Item) bool {
+	onlyA, onlyB, _ := diffSolutions(a, b)
+	return len(onlyA) == 0 && len(onlyB) == 0
+}
@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestExhaustiveSearchGrayMatchesExhaustiveSearch(t *testing.T) {
+	for seed := int64(1); seed <= 50; seed++ {
+		items, allowedWeight := seedInstance(seed, 12)
+		gray := solve("Exhaustive search, Gray code", exhaustiveSearchGray, items, allowedWeight)
+		recursive := solve("Exhaustive search", exhaustiveSearch, items, allowedWeight)
+		requireFeasible(t, items, allowedWeight, gray)
+		if !gray.Equal(recursive) {
+			t.Errorf("seed %d: Gray code search selected %v worth %d, exhaustive search %v worth %d", seed,
+				gray.SelectedIDs(), gray.Value, recursive.SelectedIDs(), recursive.Value)
+		}
+	}
+}
+
+// The Gray code search changes the running totals by one item per subset
+// and copies only when a subset wins, while exhaustiveSearch copies the
+// items at every leaf, so it's many times slower.
+func BenchmarkExhaustiveSearchGray(b *testing.B) {
+	items, allowedWeight := seedInstance(1, 18)
+	for _, alg := range []Algorithm{
+		{"gray", exhaustiveSearchGray},
+		{"recursive", exhaustiveSearch},
+	} {
+		b.Run(alg.Name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				alg.Run(copyItems(items), allowedWeight)
+			}
+		})
+	}
+}
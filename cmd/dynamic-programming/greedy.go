@@ -0,0 +1,123 @@
+package main
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/ppichugin/manning-knapsack-problem/internal/packing"
+)
+
+// Return the item indices ordered by decreasing value/weight ratio,
+// with the items whose ratios can't be cross-multiplied placed by
+// packing.RatioClass. Items with the same ratio keep their original order.
+func ratioOrder(items []Item) []int {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := items[order[i]], items[order[j]]
+		if ca, cb := packing.RatioClass(float64(a.value), a.weight), packing.RatioClass(float64(b.value), b.weight); ca != cb {
+			return ca < cb
+		}
+		return a.value*b.weight > b.value*a.weight
+	})
+	return order
+}
+
+// Take items in decreasing value/weight order while they fit.
+// Return the assignment, value of that assignment,
+// and the number of items we examined.
+func greedy(items []Item, allowedWeight int) ([]Item, int, int) {
+	totalValue := 0
+	totalWeight := 0
+	calls := 0
+	for _, i := range ratioOrder(items) {
+		calls += 1
+		countConsidered(1)
+		if totalWeight+items[i].weight <= allowedWeight {
+			items[i].isSelected = true
+			totalValue += items[i].value
+			totalWeight += items[i].weight
+		}
+	}
+	return items, totalValue, calls
+}
+
+// A max-heap of item indices ordered by item value.
+type valueHeap struct {
+	items   []Item
+	indices []int
+}
+
+func (h *valueHeap) Len() int { return len(h.indices) }
+func (h *valueHeap) Less(i, j int) bool {
+	return h.items[h.indices[i]].value > h.items[h.indices[j]].value
+}
+func (h *valueHeap) Swap(i, j int) { h.indices[i], h.indices[j] = h.indices[j], h.indices[i] }
+func (h *valueHeap) Push(x any)    { h.indices = append(h.indices, x.(int)) }
+func (h *valueHeap) Pop() any {
+	last := h.indices[len(h.indices)-1]
+	h.indices = h.indices[:len(h.indices)-1]
+	return last
+}
+
+// Run greedy, then keep swapping the one or two lowest-ratio selected items
+// for a more valuable unselected item that fits in their place.
+// Return the assignment, value of that assignment,
+// and the number of swaps we made.
+func greedyRefined(items []Item, allowedWeight int) ([]Item, int, int) {
+	items, totalValue, _ := greedy(items, allowedWeight)
+	totalWeight := sumWeights(items, false)
+	swaps := 0
+
+	for improved := true; improved; {
+		improved = false
+
+		// Find the two lowest-ratio selected items.
+		lowest := []int{}
+		order := ratioOrder(items)
+		for k := len(order) - 1; k >= 0 && len(lowest) < 2; k-- {
+			if items[order[k]].isSelected {
+				lowest = append(lowest, order[k])
+			}
+		}
+
+		// Try the unselected items from the most valuable down.
+		excluded := &valueHeap{items: items}
+		for i := range items {
+			if !items[i].isSelected {
+				excluded.indices = append(excluded.indices, i)
+			}
+		}
+		heap.Init(excluded)
+
+		for excluded.Len() > 0 && !improved {
+			e := heap.Pop(excluded).(int)
+
+			// Drop nothing, then the lowest item, then the lowest two.
+			for numDropped := 0; numDropped <= len(lowest); numDropped++ {
+				droppedValue := 0
+				droppedWeight := 0
+				for _, d := range lowest[:numDropped] {
+					droppedValue += items[d].value
+					droppedWeight += items[d].weight
+				}
+
+				if items[e].value > droppedValue &&
+					totalWeight-droppedWeight+items[e].weight <= allowedWeight {
+					for _, d := range lowest[:numDropped] {
+						items[d].isSelected = false
+					}
+					items[e].isSelected = true
+					totalValue += items[e].value - droppedValue
+					totalWeight += items[e].weight - droppedWeight
+					swaps += 1
+					improved = true
+					break
+				}
+			}
+		}
+	}
+	return items, totalValue, swaps
+}
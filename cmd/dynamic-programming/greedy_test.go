@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestGreedyRefinedLiesBetweenGreedyAndOptimum(t *testing.T) {
+	improved := false
+	for seed := int64(1); seed <= 100; seed++ {
+		items, allowedWeight := seedInstance(seed, 30)
+		plain := solve("Greedy", greedy, items, allowedWeight)
+		refined := solve("Greedy, refined", greedyRefined, items, allowedWeight)
+		optimal := solve("Dynamic programming", dynamicProgramming, items, allowedWeight)
+		requireFeasible(t, items, allowedWeight, refined)
+
+		if refined.Value < plain.Value || refined.Value > optimal.Value {
+			t.Errorf("seed %d: refined greedy found %d, outside greedy's %d and the optimum %d",
+				seed, refined.Value, plain.Value, optimal.Value)
+		}
+		improved = improved || refined.Value > plain.Value
+	}
+	if !improved {
+		t.Error("refining never improved on greedy")
+	}
+}
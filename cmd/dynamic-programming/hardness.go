@@ -0,0 +1,109 @@
+package main
+
+import "math"
+
+// Scores above this get a warning that branch and bound may be slow.
+var hardnessWarning = 0.7
+
+// The number of items at which the size factor of estimateHardness
+// reaches 1. Branch and bound handles fewer easily.
+const hardnessManyItems = 60
+
+// Return a score from 0 to 1 saying how hard the items are likely to be for
+// branch and bound. It averages three factors, each from 0 to 1:
+//
+//   - Capacity: 1 when the allowed weight is half the total weight,
+//     where the most selections fit, falling to 0 at none or all of it.
+//   - Correlation: the correlation between values and weights, or 0 if
+//     it's negative. When value tracks weight, every item looks about as
+//     good as another, so the bounds prune little.
+//   - Size: the number of items over hardnessManyItems, at most 1.
+//
+// It takes O(n) time, so it's cheap to check before a search.
+func estimateHardness(items []Item, allowedWeight int) float64 {
+	if len(items) == 0 {
+		return 0
+	}
+
+	capacity := 0.0
+	if totalWeight := sumWeights(items, true); totalWeight > 0 {
+		fraction := float64(allowedWeight) / float64(totalWeight)
+		capacity = max(0, 1-2*math.Abs(fraction-0.5))
+	}
+	correlation := max(0, valueWeightCorrelation(items))
+	size := min(1, float64(len(items))/hardnessManyItems)
+
+	return (capacity + correlation + size) / 3
+}
+
+// Return the Pearson correlation between the items' values and weights,
+// or 0 if either doesn't vary.
+func valueWeightCorrelation(items []Item) float64 {
+	n := float64(len(items))
+	meanValue := float64(sumValues(items, true)) / n
+	meanWeight := float64(sumWeights(items, true)) / n
+
+	var covariance, valueVariance, weightVariance float64
+	for _, item := range items {
+		dv := float64(item.value) - meanValue
+		dw := float64(item.weight) - meanWeight
+		covariance += dv * dw
+		valueVariance += dv * dv
+		weightVariance += dw * dw
+	}
+	if valueVariance == 0 || weightVariance == 0 {
+		return 0
+	}
+	return covariance / math.Sqrt(valueVariance*weightVariance)
+}
+
+// The fit behind estimateBnBNodes: log2 of the calls is about
+// estimateBase + estimatePerItem * n + estimatePerNearItem * near.
+const (
+	estimateBase        = 5.2
+	estimatePerItem     = 0.17
+	estimatePerNearItem = 0.11
+)
+
+// Return a rough estimate of how many calls branchAndBound will make,
+// good for telling hundreds from billions and not much more.
+//
+// It counts the near items: those whose value/weight ratio is so close to
+// the ratio of the item the LP relaxation takes a fraction of that trading
+// them in or out costs no more than the gap between the LP bound and
+// greedy's value. The bounds can't settle those, so the search has to.
+// A least-squares fit of log2(calls) to the number of items and near items,
+// over 316 random instances of 10 to 40 items with values from uncorrelated
+// to closely tracking weight, was off by a factor of about 2.3 on average,
+// against 2.9 for the number of items alone. Instances built to defeat
+// the bounds do much worse: it guesses about 1,700 calls for the 20-item
+// Chvátal instance, which takes 520,675.
+//
+// If the LP relaxation is integral and unique, branch and bound makes
+// just one call.
+func estimateBnBNodes(items []Item, allowedWeight int) int {
+	if _, ok := integralLP(items, ratioOrder(items), allowedWeight); ok || len(items) == 0 {
+		return 1
+	}
+
+	near := 0
+	lpValue, critical := lpRelaxation(items, allowedWeight)
+	if critical >= 0 {
+		lower, _ := quickBounds(items, allowedWeight)
+		gap := lpValue - float64(lower)
+		criticalRatio := float64(items[critical].value) / float64(items[critical].weight)
+		for _, item := range items {
+			if item.value < 0 || item.weight == 0 {
+				continue
+			}
+			ratio := float64(item.value) / float64(item.weight)
+			if math.Abs(ratio-criticalRatio)*float64(item.weight) <= gap {
+				near++
+			}
+		}
+	}
+
+	log2Calls := estimateBase + estimatePerItem*float64(len(items)) + estimatePerNearItem*float64(near)
+	// Don't overflow an int.
+	return int(math.Pow(2, min(log2Calls, 62)))
+}
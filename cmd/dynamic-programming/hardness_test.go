@@ -0,0 +1,42 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// Across random instances of several sizes, the log of the estimate should
+// rise and fall with the log of the calls branch and bound actually makes.
+func TestEstimateBnBNodesCorrelatesWithCalls(t *testing.T) {
+	estimates, actuals := []float64{}, []float64{}
+	for n := 10; n <= 40; n += 5 {
+		for seed := int64(1); seed <= 10; seed++ {
+			items, allowedWeight := seedInstance(seed, n)
+			_, calls, _ := countBranchAndBound(items, allowedWeight)
+			estimates = append(estimates, math.Log2(float64(estimateBnBNodes(items, allowedWeight))))
+			actuals = append(actuals, math.Log2(float64(calls)))
+		}
+	}
+
+	if r := correlation(estimates, actuals); r < 0.7 {
+		t.Errorf("the estimates' correlation with the calls is %.2f, want at least 0.7", r)
+	}
+}
+
+// Return the Pearson correlation of xs and ys.
+func correlation(xs, ys []float64) float64 {
+	n := float64(len(xs))
+	meanX, meanY := 0.0, 0.0
+	for i := range xs {
+		meanX += xs[i] / n
+		meanY += ys[i] / n
+	}
+	covariance, varianceX, varianceY := 0.0, 0.0, 0.0
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		covariance += dx * dy
+		varianceX += dx * dx
+		varianceY += dy * dy
+	}
+	return covariance / math.Sqrt(varianceX*varianceY)
+}
@@ -0,0 +1,81 @@
+package main
+
+// A DPSolver keeps the dynamic programming table for a growing list of
+// items, so adding an item extends the table by one row instead of
+// rebuilding it. Dropping the last row undoes the last add.
+type DPSolver struct {
+	allowedWeight int
+	items         []Item
+	// rows[i][w] is the best value of items 0 through i weighing at most w.
+	rows [][]int
+}
+
+// Return a solver with no items for a knapsack that holds allowedWeight.
+func NewDPSolver(allowedWeight int) *DPSolver {
+	return &DPSolver{allowedWeight, []Item{}, [][]int{}}
+}
+
+// Add an item, filling in its row of the table in O(allowedWeight) time.
+// The item's id is set to its position.
+// Return the new best value.
+func (s *DPSolver) AddItem(item Item) int {
+	item.id = len(s.items)
+	item.isSelected = false
+
+	row := make([]int, s.allowedWeight+1)
+	for w := range row {
+		valueWithout := 0
+		if len(s.rows) > 0 {
+			valueWithout = s.rows[len(s.rows)-1][w]
+		}
+		row[w] = valueWithout
+		if item.weight <= w {
+			valueWith := item.value
+			if len(s.rows) > 0 {
+				valueWith += s.rows[len(s.rows)-1][w-item.weight]
+			}
+			row[w] = max(valueWithout, valueWith)
+		}
+	}
+
+	s.items = append(s.items, item)
+	s.rows = append(s.rows, row)
+	return s.Best()
+}
+
+// Remove the last item added by dropping its row.
+// Return false if there are no items.
+func (s *DPSolver) RemoveLast() bool {
+	if len(s.items) == 0 {
+		return false
+	}
+	s.items = s.items[:len(s.items)-1]
+	s.rows = s.rows[:len(s.rows)-1]
+	return true
+}
+
+// Return the best value of the items so far.
+func (s *DPSolver) Best() int {
+	if len(s.rows) == 0 {
+		return 0
+	}
+	return s.rows[len(s.rows)-1][s.allowedWeight]
+}
+
+// Work backwards through the table to find the best selection.
+// Return a copy of the items with it marked.
+func (s *DPSolver) Items() []Item {
+	items := copyItems(s.items)
+	w := s.allowedWeight
+	for i := len(items) - 1; i >= 0; i-- {
+		valueWithout := 0
+		if i > 0 {
+			valueWithout = s.rows[i-1][w]
+		}
+		if s.rows[i][w] != valueWithout {
+			items[i].isSelected = true
+			w -= items[i].weight
+		}
+	}
+	return items
+}
@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestDPSolverMatchesFullSolveAfterEachAdd(t *testing.T) {
+	items, allowedWeight := seedInstance(3, 25)
+	solver := NewDPSolver(allowedWeight)
+	bests := []int{}
+	for n, item := range items {
+		got := solver.AddItem(item)
+		_, want, _ := dynamicProgramming(copyItems(items[:n+1]), allowedWeight)
+		if got != want {
+			t.Errorf("after %d items: best %d, want %d", n+1, got, want)
+		}
+		solution := Solution{"DPSolver", solver.Items(), got, n + 1, allowedWeight}
+		requireFeasible(t, items[:n+1], allowedWeight, solution)
+		bests = append(bests, got)
+	}
+
+	// Undoing the adds goes back through the same optima.
+	for n := len(items) - 1; n > 0; n-- {
+		if !solver.RemoveLast() {
+			t.Fatalf("couldn't remove item %d", n)
+		}
+		if got := solver.Best(); got != bests[n-1] {
+			t.Errorf("after removing down to %d items: best %d, want %d", n, got, bests[n-1])
+		}
+	}
+	if !solver.RemoveLast() || solver.RemoveLast() || solver.Best() != 0 {
+		t.Error("removing the last item didn't leave an empty solver")
+	}
+}
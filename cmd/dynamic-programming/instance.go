@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ppichugin/manning-knapsack-problem/internal/instance"
+)
+
+// Read a JSON instance in the form instance.Read reads.
+// Return the items, numbered in file order, and the allowed weight.
+func loadItemsJSON(r io.Reader) ([]Item, int, error) {
+	knapsack, err := instance.Read(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(knapsack.Items) == 0 {
+		return nil, 0, fmt.Errorf("%w in the JSON instance", instance.ErrEmptyItems)
+	}
+
+	items := make([]Item, len(knapsack.Items))
+	for i, item := range knapsack.Items {
+		items[i] = Item{id: i, blockedBy: -1, value: item.Value, weight: item.Weight,
+			name: item.Name, group: item.Group, cost: item.Cost, priority: item.PriorityOrDefault(),
+			requires: item.Requires, value2: item.Value2}
+	}
+	return items, knapsack.Capacity, nil
+}
+
+// Parse items written as space-separated value,weight pairs,
+// like "9,5 10,4 7,5". The items are numbered in order.
+func parseItems(list string) ([]Item, error) {
+	items := []Item{}
+	for i, pair := range strings.Fields(list) {
+		fields := strings.Split(pair, ",")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("item %d %q is not a value,weight pair", i, pair)
+		}
+		value, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("item %d %q has a bad value: %w", i, pair, err)
+		}
+		weight, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("item %d %q has a bad weight: %w", i, pair, err)
+		}
+		if weight < 0 {
+			return nil, fmt.Errorf("item %d %q has negative weight %d", i, pair, weight)
+		}
+		items = append(items, Item{id: i, blockedBy: -1, value: value, weight: weight, priority: 1})
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w in %q", instance.ErrEmptyItems, list)
+	}
+	return items, nil
+}
+
+// Return the items and allowed weight as a JSON instance.
+// The items' ids must be their positions.
+func toInstance(items []Item, allowedWeight int) instance.Instance {
+	knapsack := instance.Instance{Capacity: allowedWeight, Items: make([]instance.Item, len(items))}
+	for i, item := range items {
+		var priority *float64
+		if item.priority != 1 {
+			// Copy it, since item is the same variable on every iteration.
+			p := item.priority
+			priority = &p
+		}
+		knapsack.Items[i] = instance.Item{Value: item.value, Weight: item.weight, Name: item.name,
+			Group: item.group, Cost: item.cost, Priority: priority, Requires: item.requires, Value2: item.value2}
+	}
+	return knapsack
+}
+
+// Write the items and allowed weight as a JSON instance
+// that loadItemsJSON can read.
+func saveItemsJSON(w io.Writer, items []Item, allowedWeight int) error {
+	return toInstance(items, allowedWeight).Write(w)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestItemsJSONRoundTrip(t *testing.T) {
+	items := []Item{newItem(0, 9, 5), newItem(1, 10, 4), newItem(2, -7, 5)}
+	items[0].priority = 2
+	items[1].name = "tent"
+	items[1].group = 3
+	items[1].cost = 40
+	items[1].priority = 0.5
+	items[2].requires = []int{1}
+	items[2].value2 = 6
+
+	var buf bytes.Buffer
+	if err := saveItemsJSON(&buf, items, 12); err != nil {
+		t.Fatal(err)
+	}
+	loaded, capacity, err := loadItemsJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if capacity != 12 {
+		t.Errorf("capacity %d, want 12", capacity)
+	}
+	if !reflect.DeepEqual(loaded, items) {
+		t.Errorf("loaded %+v, want %+v", loaded, items)
+	}
+}
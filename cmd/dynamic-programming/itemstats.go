@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// How much of the item space a solver touched. This says more than Calls
+// about how solvers differ. Solvers that don't count something leave it 0.
+// The counts are atomic because parallel exhaustive search shares them.
+type itemCounts struct {
+	considered atomic.Int64 // Times an item was tried for the solution.
+	dominated  atomic.Int64 // Times Rod's technique skipped an item that an unselected item dominated.
+	excluded   atomic.Int64 // Items removed before solving, like negative or dominated items.
+}
+
+// If set, the solvers add their item counts here.
+// Counting costs a little, so it's only set with -report-items-considered
+// or -verbose.
+var itemStats *itemCounts
+
+// Return a one-line summary of the counts.
+func (counts *itemCounts) String() string {
+	return fmt.Sprintf("Items: considered %d, dominated %d, excluded %d",
+		counts.considered.Load(), counts.dominated.Load(), counts.excluded.Load())
+}
+
+// Count n more items tried for the solution, if counting.
+func countConsidered(n int) {
+	if itemStats != nil {
+		itemStats.considered.Add(int64(n))
+	}
+}
+
+// Count an item that Rod's technique skipped as dominated, if counting.
+func countDominated() {
+	if itemStats != nil {
+		itemStats.dominated.Add(1)
+	}
+}
+
+// Count n items removed before solving, if counting.
+func countExcluded(n int) {
+	if itemStats != nil {
+		itemStats.excluded.Add(int64(n))
+	}
+}
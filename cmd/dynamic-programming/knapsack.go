@@ -0,0 +1,28 @@
+package main
+
+// An Algorithm is a named knapsack solver.
+type Algorithm struct {
+	Name string
+	Run  func([]Item, int) ([]Item, int, int)
+}
+
+// A Knapsack bundles the items with the weight the knapsack can hold.
+type Knapsack struct {
+	Items    []Item
+	Capacity int
+}
+
+// Run the algorithm on a copy of the items and return its solution.
+func (k Knapsack) Solve(alg Algorithm) Solution {
+	return solve(alg.Name, alg.Run, k.Items, k.Capacity)
+}
+
+// Return the total value of all of the items.
+func (k Knapsack) TotalValue() int {
+	return sumValues(k.Items, true)
+}
+
+// Return the total weight of all of the items.
+func (k Knapsack) TotalWeight() int {
+	return sumWeights(k.Items, true)
+}
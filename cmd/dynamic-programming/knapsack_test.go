@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestKnapsackSolveAgreesAcrossAlgorithms(t *testing.T) {
+	items, allowedWeight := seedInstance(5, 20)
+	k := Knapsack{items, allowedWeight}
+	if k.TotalWeight() <= k.Capacity {
+		t.Fatalf("all of the items weigh %d, so capacity %d doesn't constrain them", k.TotalWeight(), k.Capacity)
+	}
+
+	dp := k.Solve(Algorithm{"Dynamic programming", dynamicProgramming})
+	bb := k.Solve(Algorithm{"Branch and bound", branchAndBound})
+	requireFeasible(t, items, allowedWeight, dp)
+	requireFeasible(t, items, allowedWeight, bb)
+	if dp.Value != bb.Value {
+		t.Errorf("dynamic programming found %d, branch and bound %d", dp.Value, bb.Value)
+	}
+	if dp.Value > k.TotalValue() {
+		t.Errorf("the solution is worth %d, more than all of the items' %d", dp.Value, k.TotalValue())
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+// Maximize value - lambda * weight with no capacity at all, the Lagrangian
+// relaxation of the capacity constraint. The objective is a sum over the
+// items, so it just selects every item worth more than lambda times its
+// weight: the items whose value/weight ratio beats lambda.
+//
+// For lambda >= 0, the objective plus lambda times the capacity is an upper
+// bound on the knapsack's optimum, so the best lambda gives a bound like
+// the LP relaxation's. Sweeping lambda down from the largest ratio to 0
+// adds the items in ratio order, tracing the pareto frontier's upper convex
+// hull: the packings that are best for some price of weight.
+// Return a copy of the items with the selection marked,
+// and the objective's value.
+func lagrangianKnapsack(items []Item, lambda float64) ([]Item, float64) {
+	solution := copyItems(items)
+	objective := 0.0
+	for i := range solution {
+		score := float64(solution[i].value) - lambda*float64(solution[i].weight)
+		solution[i].isSelected = score > 0
+		if solution[i].isSelected {
+			objective += score
+		}
+	}
+	return solution, objective
+}
@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestLagrangianBoundsOptimum(t *testing.T) {
+	for seed := int64(1); seed <= 30; seed++ {
+		items, allowedWeight := seedInstance(seed, 20)
+		_, optimum, _ := dynamicProgramming(copyItems(items), allowedWeight)
+		lp, _ := lpRelaxation(items, allowedWeight)
+
+		for _, lambda := range []float64{0, 0.5, 1, 1.25, 1.5, 2, 5} {
+			solution, objective := lagrangianKnapsack(items, lambda)
+			if bound := objective + lambda*float64(allowedWeight); bound < float64(optimum) {
+				t.Errorf("seed %d, lambda %g: bound %g is less than the optimum %d", seed, lambda, bound, optimum)
+			} else if bound < lp-1e-9 {
+				t.Errorf("seed %d, lambda %g: bound %g is tighter than the LP relaxation's %g", seed, lambda, bound, lp)
+			}
+
+			// The selection is worth the objective.
+			want := 0.0
+			for _, item := range solution {
+				if item.isSelected {
+					want += float64(item.value) - lambda*float64(item.weight)
+				}
+			}
+			if objective != want {
+				t.Errorf("seed %d, lambda %g: objective %g, but the selection scores %g", seed, lambda, objective, want)
+			}
+		}
+	}
+}
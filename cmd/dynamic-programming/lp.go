@@ -0,0 +1,133 @@
+package main
+
+import "sort"
+
+// Solve the LP relaxation, where items may be taken fractionally.
+// Items with negative values are never taken.
+// Taking items in decreasing value/weight order and a fraction of the
+// first one that doesn't fit is optimal, so the relaxed value is an
+// upper bound on the value of any real solution.
+// Return the relaxed value and the index of the item taken fractionally,
+// or -1 if the relaxation is integral.
+func lpRelaxation(items []Item, allowedWeight int) (float64, int) {
+	return relaxedValue(items, ratioOrder(items), 0, allowedWeight)
+}
+
+// Solve the LP relaxation for items from and later,
+// visiting them in the given value/weight order.
+func relaxedValue(items []Item, order []int, from, allowedWeight int) (float64, int) {
+	value := 0.0
+	remaining := allowedWeight
+	for _, i := range order {
+		if i < from || items[i].value < 0 {
+			continue
+		}
+		if items[i].weight <= remaining {
+			value += float64(items[i].value)
+			remaining -= items[i].weight
+			continue
+		}
+		if remaining > 0 {
+			value += float64(items[i].value) * float64(remaining) / float64(items[i].weight)
+			return value, i
+		}
+		break
+	}
+	return value, -1
+}
+
+// Return which items the LP relaxation takes, visiting them in the given
+// value/weight order, and true if it takes no fraction of any item and
+// no other solution of the relaxation is as good. Then the selection is
+// the only optimal one, so branch and bound needn't search.
+// That holds when every item taken is worth something and beats every
+// item left out on value/weight, so no exchange can break even.
+func integralLP(items []Item, order []int, allowedWeight int) ([]bool, bool) {
+	taken := make([]bool, len(items))
+	remaining := allowedWeight
+	last := -1
+	for _, i := range order {
+		if items[i].value < 0 {
+			continue
+		}
+		if items[i].weight > remaining {
+			if remaining > 0 {
+				// The relaxation takes part of this item.
+				return nil, false
+			}
+			break
+		}
+		if items[i].value == 0 {
+			return nil, false
+		}
+		taken[i] = true
+		remaining -= items[i].weight
+		last = i
+	}
+
+	for _, i := range order {
+		if taken[i] || items[i].value < 0 {
+			continue
+		}
+		if last < 0 || items[last].value*items[i].weight <= items[i].value*items[last].weight {
+			return nil, false
+		}
+	}
+	return taken, true
+}
+
+// The LP relaxations of every suffix of the items, precomputed so branch
+// and bound can bound a node in O(log n) time instead of walking the
+// whole value/weight order at each one.
+//
+// For each from, the items from and later, leaving out penalty items, are
+// listed in value/weight order with running totals of their weights and
+// values. The relaxation takes whole items while they fit, which is the
+// longest run whose total weight fits, found by binary search, plus
+// a fraction of the next item. That's O(n^2) space, which is small for
+// the item counts branch and bound can handle.
+//
+// The bounds are the same as walking the order, so the calls are too.
+// BenchmarkSuffixLP reports them and BenchmarkSuffixLPBound compares
+// the two ways of bounding. branchAndBoundLooseBound shows that the
+// bound more than pays for itself either way.
+type suffixLP struct {
+	items   []Item
+	order   [][]int // order[from] lists the items from and later.
+	weights [][]int // weights[from][k] is the weight of the first k items in order[from].
+	values  [][]int // values[from][k] is their value.
+}
+
+// Precompute the relaxations of the suffixes, given the items' value/weight order.
+func newSuffixLP(items []Item, order []int) *suffixLP {
+	lp := &suffixLP{items, make([][]int, len(items)+1), make([][]int, len(items)+1), make([][]int, len(items)+1)}
+	for from := range lp.order {
+		lp.weights[from] = []int{0}
+		lp.values[from] = []int{0}
+		for _, i := range order {
+			if i < from || items[i].value < 0 {
+				continue
+			}
+			k := len(lp.order[from])
+			lp.order[from] = append(lp.order[from], i)
+			lp.weights[from] = append(lp.weights[from], lp.weights[from][k]+items[i].weight)
+			lp.values[from] = append(lp.values[from], lp.values[from][k]+items[i].value)
+		}
+	}
+	return lp
+}
+
+// Return what relaxedValue returns for items from and later:
+// the relaxed value and the index of the item taken fractionally, or -1.
+func (lp *suffixLP) bound(from, allowedWeight int) (float64, int) {
+	weights := lp.weights[from]
+	// Find the most items k whose weights fit.
+	k := sort.Search(len(weights), func(k int) bool { return weights[k] > allowedWeight }) - 1
+	value := float64(lp.values[from][k])
+	remaining := allowedWeight - weights[k]
+	if k == len(lp.order[from]) || remaining == 0 {
+		return value, -1
+	}
+	i := lp.order[from][k]
+	return value + float64(lp.items[i].value)*float64(remaining)/float64(lp.items[i].weight), i
+}
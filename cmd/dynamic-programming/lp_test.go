@@ -0,0 +1,80 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIntegralLPSkipsSearch(t *testing.T) {
+	// The two best items by value/weight fill the capacity exactly,
+	// and beat the rest, so the LP relaxation takes no fraction.
+	items := []Item{newItem(0, 4, 4), newItem(1, 10, 2), newItem(2, 1, 5), newItem(3, 9, 3)}
+	allowedWeight := 5
+
+	taken, ok := integralLP(items, ratioOrder(items), allowedWeight)
+	if !ok {
+		t.Fatal("integralLP found no integral relaxation")
+	}
+	if !slices.Equal(taken, []bool{false, true, false, true}) {
+		t.Errorf("integralLP took %v, want items 1 and 3", taken)
+	}
+
+	byBnB := solve("Branch and bound", branchAndBound, items, allowedWeight)
+	requireFeasible(t, items, allowedWeight, byBnB)
+	if byBnB.Calls != 1 {
+		t.Errorf("branch and bound made %d calls, want 1", byBnB.Calls)
+	}
+	byExhaustive := solve("Exhaustive search", exhaustiveSearch, items, allowedWeight)
+	if !byBnB.Equal(byExhaustive) {
+		t.Errorf("branch and bound selected %v worth %d, exhaustive search %v worth %d",
+			byBnB.SelectedIDs(), byBnB.Value, byExhaustive.SelectedIDs(), byExhaustive.Value)
+	}
+
+	// One more unit of capacity takes part of item 0, so the search runs.
+	if _, ok := integralLP(items, ratioOrder(items), allowedWeight+1); ok {
+		t.Error("integralLP called a fractional relaxation integral")
+	}
+}
+
+// Run branch and bound, which bounds each node with suffixLP, on the
+// 40-item instances for seeds 1 to 50, reporting the calls alongside
+// the time.
+func BenchmarkSuffixLP(b *testing.B) {
+	instances := make([][]Item, 50)
+	capacities := make([]int, 50)
+	for i := range instances {
+		instances[i], capacities[i] = seedInstance(int64(i+1), 40)
+	}
+	calls := 0
+	for n := 0; n < b.N; n++ {
+		calls = 0
+		for i, items := range instances {
+			_, _, c := branchAndBound(copyItems(items), capacities[i])
+			calls += c
+		}
+	}
+	b.ReportMetric(float64(calls), "calls/op")
+}
+
+// Compare bounding every suffix by walking the value/weight order
+// with looking it up in the precomputed table.
+func BenchmarkSuffixLPBound(b *testing.B) {
+	items, allowedWeight := seedInstance(1337, 40)
+	order := ratioOrder(items)
+	b.Run("walk", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			for from := range items {
+				relaxedValue(items, order, from, allowedWeight)
+			}
+		}
+	})
+	b.Run("table", func(b *testing.B) {
+		lp := newSuffixLP(items, order)
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			for from := range items {
+				lp.bound(from, allowedWeight)
+			}
+		}
+	})
+}
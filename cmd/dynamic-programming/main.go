@@ -1,9 +1,15 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"io"
+	"math"
 	"math/rand"
+	"os"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -16,14 +22,133 @@ const maxWeight = 10
 
 var allowedWeight int
 
+var capacityFraction = flag.Float64("capacity-fraction", 0.5,
+	"allowed weight as a fraction of the items' total weight")
+var capacity = flag.Int("capacity", -1,
+	"allowed weight, overriding -capacity-fraction and the JSON capacity (-1 means unset)")
+var valueDistName = flag.String("value-dist", "uniform",
+	"distribution of random item values: uniform, normal, exponential, or constant")
+var balancedWeight = flag.Int("balanced-weight", 0,
+	"make random items whose weights add up to exactly this, with half of it as the allowed weight (0 means don't)")
+var inlineItems = flag.String("items", "",
+	`the items as space-separated value,weight pairs, like "9,5 10,4 7,5"`)
+
+var fptasFrontier = flag.Bool("fptas-frontier", false,
+	"print a CSV of FPTAS results for several epsilons and exit")
+var dryRun = flag.Bool("dry-run", false,
+	"print the estimated cost of solving and exit without solving")
+var ranking = flag.Bool("ranking", false,
+	"print a CSV of the items in value/weight order, marking the optimal selection, and exit")
+var pareto = flag.Bool("pareto", false,
+	"print a CSV of the best value for every packing weight and exit")
+var seedSearch = flag.Int("seed-search", 0,
+	"try this many seeds on small instances, print the one where greedy is furthest from optimal, and exit")
+var sahniK = flag.Int("sahni-k", -1,
+	"also run Sahni's approximation with base subsets of up to this many items (-1 means don't)")
+var compareSeeds = flag.String("compare-seeds-csv", "",
+	"print a CSV of each algorithm's results on the instance for each seed in a range like 1-100, and exit")
+var compareNames = flag.String("algorithms", "greedy,greedy-refined,dp,bnb",
+	"comma-separated algorithms for -compare-seeds-csv: exhaustive, bnb, bnb-loose, rods, greedy, greedy-refined, dp, or memo")
+var outputDir = flag.String("output-dir", "",
+	"also write each -compare-seeds-csv algorithm's results to <name>.json in this directory")
+var seedItems = flag.Int("seed-items", 20,
+	"the number of items in each -compare-seeds-csv instance")
+var sensitivity = flag.Int("sensitivity", 0,
+	"also report the best values with this much less and more capacity")
+var lambda = flag.Float64("lambda", -1,
+	"also select the items that maximize value - lambda * weight, ignoring the capacity (negative means don't)")
+var exactlyK = flag.Int("exactly-k", 0,
+	"also find the best solution with exactly this many items")
+var reserve = flag.Int("reserve", 0,
+	"also find the best solution that leaves at least this much of the allowed weight unused")
+var minTotalWeight = flag.Int("min-weight", 0,
+	"also find the best solution weighing at least this much")
+var costBudget = flag.Int("cost-budget", 0,
+	"also find the best solution whose items cost at most this much")
+var bins = flag.String("bins", "",
+	"also pack the items into knapsacks with these comma-separated capacities")
+var nearOptimal = flag.Float64("near-optimal", 0,
+	"also count the selections worth at least this fraction of the optimum (small instances only)")
+var shuffleSeed = flag.Int64("shuffle-seed", 0,
+	"permute the items with this seed before solving, to study order sensitivity (0 means don't)")
+var shuffleRuns = flag.Int("shuffle-runs", 0,
+	"also summarize branch and bound calls over this many shuffles, from -shuffle-seed up")
+var dumpFile = flag.String("dump", "",
+	"write the exact instance to this file before solving, for -replay")
+var replayFile = flag.String("replay", "",
+	"load an exact instance written by -dump")
+var jsonFile = flag.String("json", "",
+	"load the items and allowed weight from this JSON file")
+var topItems = flag.Int("top", 0,
+	"only print this many of the most valuable selected items (0 prints them all)")
+var timeout = flag.Duration("timeout", 0,
+	"give up on branch and bound or an external solver after this long, even with many items (0 means no limit)")
+var externalAlgorithm = flag.String("algo", "",
+	"also run the program at this path as a solver, written as exec:./my-solver; see runExternalSolver for the protocol")
+var runNames = flag.String("run", "",
+	"comma-separated algorithm families to run besides Rod's technique and dynamic programming: exhaustive, bnb, greedy, memo, dominance, auto, or all")
+var verbose = flag.Bool("verbose", false,
+	"also print the instance's dominated items and bounds, the items each algorithm left behind, why branch and bound pruned, and the second-best value")
+var checkAgreement = flag.Bool("check-agreement", true,
+	"exit non-zero, printing the instance and both solutions, if dynamic programming and branch and bound (-run bnb) find different values")
+var checkDP = flag.Bool("check-dp", false,
+	"check that the dynamic programming selection matches its tables, and exit non-zero if not")
+
+var stream = flag.Bool("stream", false,
+	"print each improving branch and bound solution as it's found, then solve with the items sent one at a time (stops at -timeout if set)")
+var preferName = flag.String("prefer", "canonical",
+	"how exhaustive search breaks value ties: canonical, fewer, more, or lighter")
+var compareWithoutCheck = flag.Bool("compare-without-check", false,
+	"also run branch and bound without its check before leaving out an item, and compare calls")
+var warmStartFile = flag.String("warm-start", "",
+	"also run branch and bound starting from the solution in this JSON file, like {\"selected\": [0, 3]}")
+var checklist = flag.Bool("checklist", false,
+	"print the best packing as a checklist of items to pack, and exit")
+var countOnly = flag.Bool("count-only", false,
+	"only count branch and bound's calls and prunes, without keeping a solution, and exit")
+var chvatal = flag.Int("chvatal", 0,
+	"print branch and bound's calls on the hard Chvátal instances of 1 to this many items, and exit")
+var objective = flag.String("objective", objectiveMaxValue,
+	"max-value packs the most value into the allowed weight; min-weight finds the lightest items worth -value-floor, ignoring the allowed weight")
+var valueFloor = flag.Int("value-floor", 0,
+	"the value -objective=min-weight must reach")
+var epsilon = flag.Float64("epsilon", 0,
+	"also run branch and bound that only looks for solutions within this fraction of optimal")
+var reportItemsConsidered = flag.Bool("report-items-considered", false,
+	"after each algorithm, report how many items it considered, skipped as dominated, and removed up front")
+var compareLooseBound = flag.Bool("compare-loose-bound", false,
+	"also run branch and bound without its LP relaxation bound, and compare calls")
+var explain = flag.Bool("explain", false,
+	"narrate how dynamic programming reconstructs its solution (table format only)")
+var formatName = flag.String("format", "table",
+	"output format: table, csv, or json")
+var forceList = flag.String("force", "",
+	"comma-separated ids of items that every algorithm must select, along with their prerequisites")
+
+// The ids of the items that runAlgorithm makes every algorithm select,
+// from -force.
+var forcedIDs Selection
+
+// Where the results go, as picked by -format.
+var output formatter
+
 type Item struct {
 	id, blockedBy int
 	blockList     []int // Other items that this one blocks.
 	value, weight int
 	isSelected    bool
+	name          string  // Optional, for items loaded from a file.
+	group         int     // Items in the same group exclude each other. 0 means no group.
+	cost          int     // What the item costs, for costConstrainedKnapsack.
+	priority      float64 // Multiplies the value in prioritizedKnapsack. 1 is neutral.
+	requires      []int   // Items that must be selected for this one to be, for prerequisiteSearch.
+	value2        int     // A second kind of value, for maxMinKnapsack.
 }
 
 // Test results:
+// makeItems uses a changing seed, so these are only examples.
+// Each run makes different items and gets different values.
+//
 // *** Parameters ***
 // # items: 200
 // Total value: 1177
@@ -52,48 +177,606 @@ type Item struct {
 // Value: 2059, Weight: 1776, Calls: 1
 
 func main() {
-	items := makeItems(numItems, minValue, maxValue, minWeight, maxWeight)
-	allowedWeight = sumWeights(items, true) / 2
+	flag.Parse()
+	if *capacityFraction < 0 {
+		fmt.Fprintln(os.Stderr, "-capacity-fraction must not be negative")
+		os.Exit(2)
+	}
+	if *epsilon < 0 || *epsilon >= 1 {
+		fmt.Fprintln(os.Stderr, "-epsilon must be at least 0 and less than 1")
+		os.Exit(2)
+	}
+	switch *objective {
+	case objectiveMaxValue:
+	case objectiveMinWeight:
+		if *valueFloor <= 0 {
+			fmt.Fprintln(os.Stderr, "-objective=min-weight needs a positive -value-floor")
+			os.Exit(2)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown -objective %q\n", *objective)
+		os.Exit(2)
+	}
+	if *externalAlgorithm != "" && !strings.HasPrefix(*externalAlgorithm, "exec:") {
+		fmt.Fprintf(os.Stderr, "-algo %q must look like exec:./my-solver\n", *externalAlgorithm)
+		os.Exit(2)
+	}
+
+	families, err := parseRunFamilies(*runNames)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	// The branch and bound comparisons need branch and bound.
+	if *compareWithoutCheck || *compareLooseBound || *epsilon > 0 || *warmStartFile != "" {
+		families["bnb"] = true
+	}
+
+	preference, ok := tiePreferenceNames[*preferName]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown -prefer %q\n", *preferName)
+		os.Exit(2)
+	}
+
+	var items []Item
+	if *replayFile != "" {
+		data, err := os.ReadFile(*replayFile)
+		if err == nil {
+			items, allowedWeight, err = loadInstance(data)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	} else if *inlineItems != "" {
+		var err error
+		items, err = parseItems(*inlineItems)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "-items:", err)
+			os.Exit(2)
+		}
+		allowedWeight = int(float64(sumWeights(items, true)) * *capacityFraction)
+	} else if *jsonFile != "" {
+		// Load the instance from the file.
+		file, err := os.Open(*jsonFile)
+		if err == nil {
+			items, allowedWeight, err = loadItemsJSON(file)
+			file.Close()
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	} else {
+		valueDist, ok := valueDists[*valueDistName]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown -value-dist %q\n", *valueDistName)
+			os.Exit(2)
+		}
+		if *balancedWeight > 0 {
+			if *balancedWeight < numItems {
+				fmt.Fprintf(os.Stderr, "-balanced-weight must be at least %d, one per item\n", numItems)
+				os.Exit(2)
+			}
+			random := rand.New(rand.NewSource(time.Now().UnixNano()))
+			items = makeBalancedItems(random, numItems, *balancedWeight, valueDist)
+			allowedWeight = *balancedWeight / 2
+		} else {
+			items = makeItemsWithValues(numItems, valueDist, minWeight, maxWeight)
+			allowedWeight = int(float64(sumWeights(items, true)) * *capacityFraction)
+		}
+	}
+	if *capacity >= 0 {
+		allowedWeight = *capacity
+	}
+	originalItems := items
+	var permutation []int
+	if *shuffleSeed != 0 {
+		items, permutation = shuffleItems(items, *shuffleSeed)
+	}
+	if *dumpFile != "" {
+		if err := os.WriteFile(*dumpFile, dumpInstance(items, allowedWeight), 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// Check the forced items before running anything.
+	if *forceList != "" {
+		ids, err := parseIDs(*forceList)
+		if err == nil && permutation != nil {
+			// The ids are the items' positions before the shuffle.
+			newPosition := make([]int, len(permutation))
+			for i, p := range permutation {
+				newPosition[p] = i
+			}
+			for k, id := range ids {
+				if id >= 0 && id < len(newPosition) {
+					ids[k] = newPosition[id]
+				}
+			}
+		}
+		if err == nil {
+			_, err = forcedItems(items, allowedWeight, ids)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "-force:", err)
+			os.Exit(1)
+		}
+		forcedIDs = ids
+	}
+
+	// Check the warm start before running anything.
+	var warmStartSelection Selection
+	var warmStartValue int
+	if *warmStartFile != "" {
+		file, err := os.Open(*warmStartFile)
+		if err == nil {
+			warmStartSelection, err = loadSelection(file)
+			file.Close()
+		}
+		if err == nil {
+			warmStartValue, _, err = checkSelection(items, allowedWeight, warmStartSelection)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "-warm-start:", err)
+			os.Exit(1)
+		}
+	}
+
+	if *compareSeeds != "" {
+		firstSeed, lastSeed, err := parseSeedRange(*compareSeeds)
+		var names []string
+		if err == nil {
+			names, err = parseAlgorithms(*compareNames)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		if err := writeCompareSeeds(os.Stdout, firstSeed, lastSeed, *seedItems, names, *outputDir); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *seedSearch > 0 {
+		printSeedSearch(*seedSearch)
+		return
+	}
+	if *dryRun {
+		printDryRun(items, allowedWeight)
+		return
+	}
+	if *fptasFrontier {
+		printFptasFrontier(items, allowedWeight, frontierEpsilons)
+		return
+	}
+	if *ranking {
+		printRanking(os.Stdout, items, allowedWeight)
+		return
+	}
+	if *pareto {
+		printParetoFrontier(items)
+		return
+	}
+	if *countOnly {
+		printCountOnly(items, allowedWeight)
+		return
+	}
+	if *checklist {
+		solution, _ := Solve(items, allowedWeight)
+		writeChecklist(os.Stdout, solution)
+		return
+	}
+	if *chvatal > 0 {
+		printChvatalGrowth(*chvatal)
+		return
+	}
+
+	output, err = newFormatter(*formatName, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	var capacities []int
+	if *bins != "" {
+		capacities, err = parseCapacities(*bins)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "-bins:", err)
+			os.Exit(2)
+		}
+	}
 
 	// Display basic parameters.
-	fmt.Println("*** Parameters ***")
-	fmt.Printf("# items: %d\n", numItems)
-	fmt.Printf("Total value: %d\n", sumValues(items, true))
-	fmt.Printf("Total weight: %d\n", sumWeights(items, true))
-	fmt.Printf("Allowed weight: %d\n", allowedWeight)
-	fmt.Println()
+	output.parameters(items, allowedWeight)
+	if permutation != nil {
+		output.note(fmt.Sprintf("Shuffled with seed %d, item i was item perm[i]: perm=%v", *shuffleSeed, permutation))
+	}
+
+	// Covering instead of packing
+	if *objective == objectiveMinWeight {
+		selection, weight, value, ok := minWeightForValue(items, *valueFloor)
+		if ok {
+			output.note(fmt.Sprintf("Lightest worth at least %d: %v, Value: %d, Weight: %d",
+				*valueFloor, selection, value, weight))
+		} else {
+			output.note(fmt.Sprintf("No selection is worth at least %d", *valueFloor))
+		}
+		if err := output.flush(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *shuffleRuns > 0 {
+		if len(originalItems) > 45 {
+			output.note("Too many items to shuffle branch and bound")
+		} else {
+			output.note(shuffleCallStats(originalItems, allowedWeight, *shuffleSeed, *shuffleRuns))
+		}
+	}
+
+	// Exhaustive search
+	if families["exhaustive"] {
+		if len(items) > 25 { // Only run exhaustive search if numItems <= 25.
+			output.note("Too many items for exhaustive search")
+		} else {
+			runAlgorithm("Exhaustive Search", exhaustiveSearchPreferring(preference), items, allowedWeight)
+			runAlgorithm("Parallel exhaustive search", parallelExhaustiveSearchPreferring(preference), items, allowedWeight)
+			runAlgorithm("Exhaustive search, Gray code", exhaustiveSearchGrayPreferring(preference), items, allowedWeight)
+		}
+	}
+
+	// Branch and bound
+	var byBnB *Solution
+	if families["bnb"] {
+		if hardness := estimateHardness(items, allowedWeight); hardness > hardnessWarning {
+			output.note(fmt.Sprintf("Hardness %.2f: this instance is likely hard for branch and bound", hardness))
+		}
+		if len(items) > 45 && *timeout == 0 { // Only run branch and bound if numItems <= 45.
+			output.note("Too many items for branch and bound")
+		} else if *timeout > 0 {
+			ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+			stop := measure()
+			solution, err := branchAndBoundContext(ctx, items, allowedWeight)
+			cost := stop()
+			cancel()
+			if err != nil {
+				output.note(fmt.Sprintf("Branch and Bound: no solution: %v", err))
+			} else {
+				solution.Algorithm = "Branch and Bound"
+				output.result(solution, cost)
+				byBnB = &solution
+			}
+		} else {
+			if *verbose {
+				pruneStats = &pruneCounts{}
+			}
+			with := runAlgorithm("Branch and Bound", branchAndBound, items, allowedWeight)
+			byBnB = &with
+			if _, ok := integralLP(items, ratioOrder(items), allowedWeight); ok {
+				output.note("Branch and Bound: optimal by integral LP bound")
+			}
+			if pruneStats != nil {
+				output.note(pruneStats.String())
+				pruneStats = nil
+			}
+			if order := ratioOrder(items); *verbose && len(order) > 0 {
+				// The bound on leaving out the best-ratio item, two ways.
+				first := items[order[0]]
+				output.note(fmt.Sprintf("Leaving out item %d at the root: LP bound %d, total value bound %d",
+					first.id, fractionalBoundExcluding(items, allowedWeight, first.id),
+					sumValues(items, true)-first.value))
+			}
+
+			if *compareWithoutCheck {
+				without := runAlgorithm("Branch and Bound, no without-item check",
+					branchAndBoundNoWithoutCheck, items, allowedWeight)
+				output.note(fmt.Sprintf("Without-item check: Calls %d with, %d without, same value: %t",
+					with.Calls, without.Calls, with.Value == without.Value))
+			}
+			if *compareLooseBound {
+				loose := runAlgorithm("Branch and Bound, loose bound",
+					branchAndBoundLooseBound, items, allowedWeight)
+				output.note(fmt.Sprintf("LP bound: Calls %d tight, %d loose, same value: %t",
+					with.Calls, loose.Calls, with.Value == loose.Value))
+			}
+			if *epsilon > 0 {
+				near := runAlgorithm(fmt.Sprintf("Branch and Bound, epsilon=%g", *epsilon),
+					branchAndBoundEpsilon(*epsilon), items, allowedWeight)
+				output.note(fmt.Sprintf("Epsilon %g: Value %d, so the optimum is at most %d; Calls %d near, %d exact",
+					*epsilon, near.Value, int(float64(near.Value)/(1-*epsilon)), near.Calls, with.Calls))
+			}
+			if *warmStartFile != "" {
+				warm := runWithPenalties("Branch and Bound, warm start", branchAndBoundFrom(warmStartSelection), items, allowedWeight)
+				output.note(fmt.Sprintf("Warm start worth %d: Calls %d warm, %d cold, same value: %t",
+					warmStartValue, warm.Calls, with.Calls, with.Value == warm.Value))
+			}
+		}
+	}
+
+	// Branch and bound, reporting each improvement
+	if *stream {
+		ctx := context.Background()
+		if *timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, *timeout)
+			defer cancel()
+		}
+		for solution := range solveStream(ctx, items, allowedWeight) {
+			output.note("Improved: " + solution.String())
+		}
+
+		// Dynamic programming on the items as a producer sends them.
+		itemsCh := make(chan Item)
+		go func() {
+			defer close(itemsCh)
+			for _, item := range items {
+				select {
+				case itemsCh <- item:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		stop := measure()
+		solution, err := solveStreaming(ctx, itemsCh, allowedWeight)
+		cost := stop()
+		if err != nil {
+			output.note(fmt.Sprintf("Dynamic programming, streamed: no solution: %v", err))
+		} else {
+			output.result(solution, cost)
+		}
+	}
 
 	// Rod's technique sorted
-	if numItems > 350 { // Only use Rod's technique if numItems <= 350.
-		fmt.Println("Too many items for Rod's technique\n")
+	if len(items) > 350 { // Only use Rod's technique if numItems <= 350.
+		output.note("Too many items for Rod's technique")
 	} else {
-		fmt.Println("*** Rod's technique Sorted ***")
-		runAlgorithm(rodsTechniqueSorted, items, allowedWeight)
+		runAlgorithm("Rod's technique Sorted", rodsTechniqueSorted, items, allowedWeight)
+	}
+
+	// Greedy, and greedy with swaps
+	if families["greedy"] {
+		runAlgorithm("Greedy", greedy, items, allowedWeight)
+		runAlgorithm("Greedy refined", greedyRefined, items, allowedWeight)
+	}
+
+	// Greedy from every small base subset
+	if *sahniK >= 0 {
+		runAlgorithm(fmt.Sprintf("Sahni k=%d", *sahniK), func(items []Item, allowedWeight int) ([]Item, int, int) {
+			return sahniApprox(items, allowedWeight, *sahniK)
+		}, items, allowedWeight)
 	}
 
 	// Dynamic programming
-	fmt.Println("*** Dynamic programming ***")
-	runAlgorithm(dynamicProgramming, items, allowedWeight)
+	best := runAlgorithm("Dynamic programming", dynamicProgramming, items, allowedWeight)
+	if *checkDP {
+		if err := checkDynamicProgramming(best.Items, allowedWeight); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			output.flush()
+			os.Exit(1)
+		}
+	}
+	if *checkAgreement && byBnB != nil && !agreeOnValue(os.Stderr, items, allowedWeight, best, *byBnB) {
+		output.flush()
+		os.Exit(1)
+	}
+	if *verbose {
+		if second := secondBestValue(items, allowedWeight); second < 0 {
+			output.note("Second-best value: none, every selection is worth the same")
+		} else {
+			output.note(fmt.Sprintf("Second-best value: %d, gap %d", second, best.Value-second))
+		}
+	}
+	if _, isTable := output.(*tableFormatter); *explain && isTable {
+		width := tableWeight(items, allowedWeight)
+		_, prevWeight := fillDynamicProgrammingTable(items, width)
+		explainReconstruction(items, width, prevWeight, maxExplainLines)
+		fmt.Println()
+	}
+
+	// Memoized dynamic programming
+	if families["memo"] {
+		runAlgorithm("Memoized dynamic programming", memoizedDynamicProgramming, items, allowedWeight)
+	}
+
+	// Dynamic programming without the dominated items
+	if families["dominance"] {
+		runAlgorithm("Dynamic programming, dominated items removed",
+			withoutDominatedItems(dynamicProgramming), items, allowedWeight)
+		if *verbose {
+			efficient := efficientItems(items)
+			output.note(fmt.Sprintf("Undominated items: %d of %d: %v", len(efficient), len(items), efficient))
+		}
+	}
+
+	// At most one item per group
+	if hasGroups(items) {
+		solution := runAlgorithm("Multiple-choice knapsack", multipleChoiceKnapsack, items, allowedWeight)
+		choices := groupChoices(solution.Items)
+		lines := []string{}
+		for _, group := range chosenGroups(choices) {
+			lines = append(lines, fmt.Sprintf("Group %d: item %d", group, choices[group]))
+		}
+		output.note(strings.Join(lines, "\n"))
+	}
+
+	// Weight the values by priority
+	if hasPriorities(items) {
+		byDP := runAlgorithm("Dynamic programming, by priority", withPriorities(dynamicProgramming), items, allowedWeight)
+		byBnB := runAlgorithm("Branch and bound, by priority", prioritizedBranchAndBound, items, allowedWeight)
+		byGreedy := runAlgorithm("Greedy, by priority", prioritizedGreedy, items, allowedWeight)
+		output.note(fmt.Sprintf("Priority-weighted value: dynamic programming %.2f, branch and bound %.2f, greedy %.2f",
+			prioritizedValue(byDP.Items), prioritizedValue(byBnB.Items), prioritizedValue(byGreedy.Items)))
+	}
+
+	// Balance the two kinds of value
+	if hasValue2(items) {
+		selection, smaller, value, value2, err := maxMinKnapsack(items, allowedWeight)
+		if err == nil {
+			output.note(fmt.Sprintf("Max-min of value and value2: %v, Value: %d, Value2: %d, min %d",
+				selection, value, value2, smaller))
+		} else {
+			output.note(err.Error())
+		}
+	}
+
+	// Only take items along with their prerequisites
+	if hasPrerequisites(items) {
+		solution := runWithPenalties("Branch and bound with prerequisites", prerequisiteSearch, items, allowedWeight)
+		if !prerequisitesMet(solution.Items) {
+			output.note("Branch and bound with prerequisites: a prerequisite isn't selected")
+		}
+		output.note("The other algorithms ignore prerequisites")
+	}
+
+	// The value of more or less capacity
+	if *sensitivity > 0 {
+		minus, at, plus := capacitySensitivity(items, allowedWeight, *sensitivity)
+		output.note(fmt.Sprintf("Capacity %d: %d, capacity %d: %d, capacity %d: %d",
+			max(0, allowedWeight-*sensitivity), minus, allowedWeight, at, allowedWeight+*sensitivity, plus))
+	}
+
+	// Price the weight instead of capping it
+	if *lambda >= 0 {
+		solution, objective := lagrangianKnapsack(items, *lambda)
+		output.note(fmt.Sprintf("Lagrangian, lambda=%g: %v, Value: %d, Weight: %d, value - lambda * weight: %.2f, bound on the optimum: %.2f",
+			*lambda, selectedIDs(solution), sumValues(solution, false), sumWeights(solution, false),
+			objective, objective+*lambda*float64(allowedWeight)))
+	}
+
+	// Exactly k items
+	if *exactlyK > 0 {
+		selection, value, ok := knapsackExactlyK(items, allowedWeight, *exactlyK)
+		if ok {
+			output.note(fmt.Sprintf("Exactly %d items: %v, Value: %d", *exactlyK, selection, value))
+		} else {
+			output.note(fmt.Sprintf("No %d items fit", *exactlyK))
+		}
+	}
+
+	// Room left over
+	if *reserve > 0 {
+		stop := measure()
+		solution, err := knapsackWithReserve(items, allowedWeight, *reserve)
+		cost := stop()
+		if err != nil {
+			output.note(err.Error())
+		} else {
+			output.result(solution, cost)
+			output.note(fmt.Sprintf("Reserved %d of %d, unused %d",
+				*reserve, allowedWeight, allowedWeight-sumWeights(solution.Items, false)))
+		}
+	}
+
+	// A weight between -min-weight and the allowed weight
+	if *minTotalWeight > 0 {
+		selection, value, ok := knapsackWeightRange(items, *minTotalWeight, allowedWeight)
+		if ok {
+			output.note(fmt.Sprintf("Weight in [%d, %d]: %v, Value: %d",
+				*minTotalWeight, allowedWeight, selection, value))
+		} else {
+			output.note(fmt.Sprintf("No selection weighs between %d and %d", *minTotalWeight, allowedWeight))
+		}
+	}
+
+	// A cost budget as well as the allowed weight
+	if *costBudget > 0 {
+		selection, value := costConstrainedKnapsack(items, allowedWeight, *costBudget)
+		output.note(fmt.Sprintf("Cost at most %d: %v, Value: %d", *costBudget, selection, value))
+	}
+
+	// Several knapsacks
+	if capacities != nil {
+		assignment, value := multiKnapsack(items, capacities)
+		output.note(fmt.Sprintf("Knapsacks %v: assignment %v, Value: %d", capacities, assignment, value))
+	}
+
+	// How many selections are nearly as good as the best
+	if *nearOptimal > 0 {
+		count := countNearOptimal(items, allowedWeight, *nearOptimal)
+		if count < 0 {
+			output.note(fmt.Sprintf("Too many items to count near-optimal selections (more than %d)", maxNearOptimalItems))
+		} else {
+			output.note(fmt.Sprintf("Selections worth at least %g of the optimum: %d", *nearOptimal, count))
+		}
+	}
+
+	// A solver in another program
+	if path, ok := strings.CutPrefix(*externalAlgorithm, "exec:"); ok {
+		ctx := context.Background()
+		if *timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, *timeout)
+			defer cancel()
+		}
+		stop := measure()
+		solution, err := runExternalSolver(ctx, path, items, allowedWeight)
+		cost := stop()
+		if err != nil {
+			output.note(fmt.Sprintf("External solver failed: %v", err))
+		} else {
+			output.result(solution, cost)
+		}
+	}
+
+	// Let Solve pick the algorithm and prove its result
+	if families["auto"] {
+		var proof OptimalityProof
+		runAlgorithm("Auto", func(items []Item, allowedWeight int) ([]Item, int, int) {
+			var solution Solution
+			solution, proof = Solve(items, allowedWeight)
+			return solution.Items, solution.Value, solution.Calls
+		}, items, allowedWeight)
+		output.note(fmt.Sprintf("Optimality proof: lower bound %d, upper bound %d, proven %t",
+			proof.LowerBound, proof.UpperBound, proof.Proven))
+	}
+
+	if err := output.flush(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
 
 // Make some random items.
 func makeItems(numItems, minValue, maxValue, minWeight, maxWeight int) []Item {
+	return makeItemsWithValues(numItems, uniformValues(minValue, maxValue), minWeight, maxWeight)
+}
+
+// Make random items whose values come from valueDist.
+func makeItemsWithValues(numItems int, valueDist func(*rand.Rand) int, minWeight, maxWeight int) []Item {
 	// Initialize a pseudorandom number generator.
 	random := rand.New(rand.NewSource(time.Now().UnixNano())) // Initialize with a changing seed
 	//random := rand.New(rand.NewSource(1337)) // Initialize with a fixed seed
+	return makeItemsFrom(random, numItems, valueDist, minWeight, maxWeight)
+}
 
+// Make random items using the given generator, so a seed reproduces them.
+func makeItemsFrom(random *rand.Rand, numItems int, valueDist func(*rand.Rand) int, minWeight, maxWeight int) []Item {
 	items := make([]Item, numItems)
 	for i := 0; i < numItems; i++ {
 		items[i] = Item{
-			i, -1, nil,
-			random.Intn(maxValue-minValue+1) + minValue,
-			random.Intn(maxWeight-minWeight+1) + minWeight,
-			false}
+			id:        i,
+			blockedBy: -1,
+			value:     valueDist(random),
+			weight:    random.Intn(maxWeight-minWeight+1) + minWeight,
+			priority:  1,
+		}
 	}
 	return items
 }
 
 // Build the items' block lists.
+// Each list holds the blocked ids in item order, so the same items always
+// get the same lists, and Rod's technique makes the same calls. Only the
+// changing seed in makeItems makes its Calls vary between runs; with a
+// fixed seed they repeat exactly. For example, seed 1 with 30 items always
+// takes 2,629 calls, which TestRodsTechniqueIsReproducible checks:
+//
+//	go run . -compare-seeds-csv 1 -seed-items 30 -algorithms rods
 func makeBlockLists(items []Item) {
 	for i := range items {
 		items[i].blockList = []int{}
@@ -126,12 +809,42 @@ func unblockItems(source Item, items []Item) {
 }
 
 // Return a copy of the items slice.
+// The copies share their block lists with the originals.
+//
+// One make and one copy is as fast as copying gets, and the cost grows with
+// the size of Item, several times that of the Item in cmd/branch-bound.
+// BenchmarkCopyItems in each package measures it. The recursive solvers
+// copy at every solution they keep, so the way to speed them up is to copy
+// less often, not faster. To rerun several algorithms on one buffer, use
+// resetSelection between them instead.
 func copyItems(items []Item) []Item {
 	newItems := make([]Item, len(items))
 	copy(newItems, items)
 	return newItems
 }
 
+// Return a copy of the items slice that also copies the block lists,
+// so changing a copy's block list doesn't change the original's.
+func deepCopyItems(items []Item) []Item {
+	newItems := copyItems(items)
+	for i := range newItems {
+		if newItems[i].blockList != nil {
+			newItems[i].blockList = append([]int{}, newItems[i].blockList...)
+		}
+	}
+	return newItems
+}
+
+// Clear the items' selections and blockers in place, so another algorithm
+// can run on the same buffer without a copy. It doesn't allocate.
+// The algorithm must not reorder the items or change anything else.
+func resetSelection(items []Item) {
+	for i := range items {
+		items[i].isSelected = false
+		items[i].blockedBy = -1
+	}
+}
+
 // Return the total value of the items.
 // If addAll is false, only add up the selected items.
 func sumValues(items []Item, addAll bool) int {
@@ -144,39 +857,134 @@ func sumValues(items []Item, addAll bool) int {
 	return total
 }
 
-// Run the algorithm. Display the elapsed time and solution.
-func runAlgorithm(alg func([]Item, int) ([]Item, int, int), items []Item, allowedWeight int) {
+// Run the algorithm without the items that have negative values
+// or are too heavy to fit, and with the -force items selected.
+// Report its cost and solution to the output.
+func runAlgorithm(name string, alg func([]Item, int) ([]Item, int, int), items []Item, allowedWeight int) Solution {
+	alg = withoutNegativeItems(withoutOverweightItems(alg))
+	if forcedIDs != nil {
+		// Outside the filters, so a forced penalty item stays selected.
+		alg = withForcedItems(forcedIDs, alg)
+	}
+	return runWithPenalties(name, alg, items, allowedWeight)
+}
+
+// Run the algorithm like runAlgorithm, but on all of the items,
+// including the ones with negative values.
+func runWithPenalties(name string, alg func([]Item, int) ([]Item, int, int), items []Item, allowedWeight int) Solution {
 	// Copy the items so the run isn't influenced by a previous run.
-	testItems := copyItems(items)
+	testItems := deepCopyItems(items)
 
-	start := time.Now()
+	stop := measure()
 
 	// Run the algorithm.
+	if *reportItemsConsidered || *verbose {
+		itemStats = &itemCounts{}
+	}
 	solution, totalValue, functionCalls := alg(testItems, allowedWeight)
 
-	elapsed := time.Since(start)
+	cost := stop()
 
-	fmt.Printf("Elapsed: %f\n", elapsed.Seconds())
-	printSelected(solution)
-	fmt.Printf("Value: %d, Weight: %d, Calls: %d\n",
-		totalValue, sumWeights(solution, false), functionCalls)
-	fmt.Println()
+	result := Solution{name, solution, totalValue, functionCalls, allowedWeight}
+	output.result(result, cost)
+	if itemStats != nil {
+		output.note(itemStats.String())
+		itemStats = nil
+	}
+	if err := checkFeasible(items, allowedWeight, result); err != nil {
+		output.note(fmt.Sprintf("Infeasible solution: %v", err))
+	}
+	return result
 }
 
-// Print the selected items.
-func printSelected(items []Item) {
+// Print the items that were left behind and the near misses among them:
+// items worth more than the cheapest selected item that
+// are too heavy for the remaining capacity.
+func printLeftovers(w io.Writer, items []Item, allowedWeight int) {
+	leftovers := unselected(items)
+	remaining := allowedWeight - sumWeights(items, false)
+	fmt.Fprintf(w, "Left behind: %d items, value %d, remaining capacity %d\n",
+		len(leftovers), sumValues(leftovers, true), remaining)
+
+	cheapest := math.MaxInt
+	for _, item := range items {
+		if item.isSelected {
+			cheapest = min(cheapest, item.value)
+		}
+	}
+
+	fmt.Fprint(w, "Near misses: ")
 	numPrinted := 0
-	for i, item := range items {
+	for _, item := range leftovers {
+		if item.value > cheapest && item.weight > remaining {
+			if numPrinted == 100 {
+				fmt.Fprint(w, "...")
+				break
+			}
+			fmt.Fprintf(w, "%d(%d, %d) ", item.id, item.value, item.weight)
+			numPrinted += 1
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+// Return the selected items' weight as a percentage of the allowed weight.
+func utilization(items []Item, allowedWeight int) float64 {
+	if allowedWeight <= 0 {
+		return 0
+	}
+	return float64(sumWeights(items, false)) * 100 / float64(allowedWeight)
+}
+
+// Print the selected items in id order, labeled by id, however the
+// algorithm ordered them. Algorithms that sort the items internally then
+// print the same line for the same selection, so the runs can be diffed.
+func printSelectedByID(w io.Writer, items []Item) {
+	selected := []Item{}
+	for _, item := range items {
 		if item.isSelected {
-			fmt.Printf("%d(%d, %d) ", i, item.value, item.weight)
+			selected = append(selected, item)
 		}
-		numPrinted += 1
-		if numPrinted > 100 {
-			fmt.Println("...")
-			return
+	}
+	sort.SliceStable(selected, func(a, b int) bool {
+		return selected[a].id < selected[b].id
+	})
+
+	for k, item := range selected {
+		if k >= 100 {
+			fmt.Fprint(w, "...")
+			break
 		}
+		fmt.Fprintf(w, "%d(%d, %d) ", item.id, item.value, item.weight)
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
+}
+
+// Print the numTop most valuable selected items
+// and a summary of the rest.
+func printTopSelected(w io.Writer, items []Item, numTop int) {
+	// Sort the selected items by value for display, breaking ties by id.
+	selected := []Item{}
+	for _, item := range items {
+		if item.isSelected {
+			selected = append(selected, item)
+		}
+	}
+	sort.Slice(selected, func(a, b int) bool {
+		if selected[a].value != selected[b].value {
+			return selected[a].value > selected[b].value
+		}
+		return selected[a].id < selected[b].id
+	})
+
+	for k, item := range selected {
+		if k >= numTop {
+			fmt.Fprintf(w, "(and %d more, total value %d)", len(selected)-numTop, sumValues(items, false))
+			break
+		}
+		fmt.Fprintf(w, "%d(%d, %d) ", item.id, item.value, item.weight)
+	}
+	fmt.Fprintln(w)
 }
 
 // Return the value of this solution.
@@ -204,18 +1012,361 @@ func sumWeights(items []Item, addAll bool) int {
 	return total
 }
 
+// Recursively assign values in or out of the solution.
+// Ties go to the selection whose ids come first in sorted order rather
+// than whatever the recursion happens to find first.
+// Return the best assignment, value of that assignment,
+// and the number of function calls we made.
+func exhaustiveSearch(items []Item, allowedWeight int) ([]Item, int, int) {
+	return doExhaustiveSearch(items, allowedWeight, 0, preferCanonical)
+}
+
+// Return an exhaustive search that breaks value ties by the preference.
+func exhaustiveSearchPreferring(prefer tiePreference) func([]Item, int) ([]Item, int, int) {
+	return func(items []Item, allowedWeight int) ([]Item, int, int) {
+		return doExhaustiveSearch(items, allowedWeight, 0, prefer)
+	}
+}
+
+func doExhaustiveSearch(items []Item, allowedWeight, nextIndex int, prefer tiePreference) ([]Item, int, int) {
+	if nextIndex >= len(items) {
+		copiedItems := copyItems(items)
+		solutionVal := solutionValue(copiedItems, allowedWeight)
+		return copiedItems, solutionVal, 1
+	}
+
+	countConsidered(1)
+	items[nextIndex].isSelected = true
+	withItem, withValue, withCalls := doExhaustiveSearch(items, allowedWeight, nextIndex+1, prefer)
+
+	items[nextIndex].isSelected = false
+	withoutItem, withoutValue, withoutCalls := doExhaustiveSearch(items, allowedWeight, nextIndex+1, prefer)
+
+	best := betterSolutionBy(candidate{withItem, withValue, withCalls},
+		candidate{withoutItem, withoutValue, withoutCalls}, prefer)
+	return best.items, best.value, withCalls + withoutCalls + 1
+}
+
+// Use branch and bound to find a solution.
+// Return the best assignment, value of that assignment,
+// and the number of function calls we made.
+func branchAndBound(items []Item, allowedWeight int) ([]Item, int, int) {
+	search := &bnbSearch{minWeights: suffixMinWeights(items), order: ratioOrder(items), prunes: pruneStats}
+	return searchBranchAndBound(items, search, allowedWeight)
+}
+
+// Use branch and bound without the check that skips leaving out an item
+// when the remaining items can't beat the best solution without it.
+// Comparing its calls with branchAndBound's shows what the check saves.
+func branchAndBoundNoWithoutCheck(items []Item, allowedWeight int) ([]Item, int, int) {
+	search := &bnbSearch{minWeights: suffixMinWeights(items), order: ratioOrder(items), noWithoutCheck: true}
+	return searchBranchAndBound(items, search, allowedWeight)
+}
+
+// Use branch and bound with only the loose bound, the remaining items'
+// total value, instead of the LP relaxation.
+// Comparing its calls with branchAndBound's shows what the tight bound saves.
+// On the 40-item instance for seed 1337, the tight bound took 5,837 calls
+// and the loose bound 395,708,653. BenchmarkLPBound times both, and
+// TestTightBoundNeverMakesMoreCalls guards against a revert to the loose one.
+func branchAndBoundLooseBound(items []Item, allowedWeight int) ([]Item, int, int) {
+	search := &bnbSearch{minWeights: suffixMinWeights(items), order: ratioOrder(items), noLPBound: true}
+	return searchBranchAndBound(items, search, allowedWeight)
+}
+
+// Return a branch and bound that prunes a branch unless it could beat the
+// best solution so far by more than the fraction epsilon of its bound.
+// The result is worth at least (1 - epsilon) times the optimum, and with
+// epsilon 0 it is the optimum.
+func branchAndBoundEpsilon(epsilon float64) func([]Item, int) ([]Item, int, int) {
+	return func(items []Item, allowedWeight int) ([]Item, int, int) {
+		search := &bnbSearch{minWeights: suffixMinWeights(items), order: ratioOrder(items), epsilon: epsilon}
+		return searchBranchAndBound(items, search, allowedWeight)
+	}
+}
+
+// Run a branch and bound search from the root.
+// If the LP relaxation at the root is integral and its only optimum,
+// return that selection without searching.
+// Return the best assignment, value of that assignment,
+// and the number of function calls we made.
+func searchBranchAndBound(items []Item, search *bnbSearch, allowedWeight int) ([]Item, int, int) {
+	if !search.noLPBound {
+		search.lp = newSuffixLP(items, search.order)
+	}
+	if taken, ok := integralLP(items, search.order, allowedWeight); ok && !search.noLPBound {
+		solution := copyItems(items)
+		for i := range solution {
+			solution[i].isSelected = taken[i]
+		}
+		totalValue := sumValues(solution, false)
+		if search.improved != nil {
+			search.improved(solution, totalValue)
+		}
+		return solution, totalValue, 1
+	}
+
+	bestValue := search.incumbent
+	currentValue := 0
+	currentWeight := 0
+	remainingValue := sumValues(items, true)
+
+	solution, totalValue, functionCalls := doBranchAndBound(items, search, allowedWeight, 0,
+		bestValue, currentValue, currentWeight, remainingValue)
+	if solution == nil {
+		// Nothing beat the empty knapsack, so every branch was pruned.
+		solution = copyItems(items)
+		for i := range solution {
+			solution[i].isSelected = false
+		}
+	}
+	return solution, totalValue, functionCalls
+}
+
+// Settings and bookkeeping shared by all of the calls in one
+// branch and bound search.
+type bnbSearch struct {
+	minWeights []int        // Entry i is the smallest weight of items i and later.
+	order      []int        // The item indices in decreasing value/weight order.
+	prunes     *pruneCounts // If not nil, count why branches were pruned.
+
+	// If true, always try leaving out the next item, even when the
+	// remaining items can't beat the best solution without it.
+	noWithoutCheck bool
+
+	// If true, only bound by the remaining items' total value, not the
+	// LP relaxation, and don't check the root relaxation for integrality.
+	noLPBound bool
+	lp        *suffixLP // The LP relaxations, built by searchBranchAndBound.
+
+	// If more than 0, prune branches whose bound is less than bestValue
+	// / (1 - epsilon), so the result is within that fraction of optimal.
+	epsilon float64
+
+	// If true, don't copy the items at each solution; return the items
+	// themselves, so only the returned values and calls mean anything.
+	countOnly bool
+
+	// The value of a known feasible solution, such as a -warm-start, that
+	// the search only has to beat. If nothing beats it, the search returns
+	// a nil or worse solution and the caller falls back to the known one.
+	incumbent int
+
+	// If not nil, called with each solution that beats all earlier ones.
+	improved func(items []Item, value int)
+
+	ctx       context.Context // If not nil, stop searching when it is done.
+	calls     int             // Calls so far, used to decide when to check ctx.
+	cancelled bool            // True once ctx is done.
+}
+
+// The number of branches that each rule pruned.
+type pruneCounts struct {
+	capacity    int // The next item didn't fit.
+	bound       int // The remaining value couldn't beat the best solution.
+	lpBound     int // The LP relaxation couldn't beat the best solution.
+	noneFit     int // None of the remaining items fit.
+	withoutItem int // Leaving out the next item couldn't beat the best solution.
+}
+
+// If set, branchAndBound adds its prune counts here.
+// Counting costs a little, so it's only set with -verbose.
+var pruneStats *pruneCounts
+
+// Return a one-line summary of the counts.
+func (counts *pruneCounts) String() string {
+	return fmt.Sprintf("Pruned: capacity %d, bound %d, LP bound %d, none fit %d, without item %d",
+		counts.capacity, counts.bound, counts.lpBound, counts.noneFit, counts.withoutItem)
+}
+
+// Return true if a branch that can't be worth more than bound can't beat
+// bestValue, or, with an epsilon, can't beat it by more than that fraction
+// of the bound. bestValue is whole, so a fractional bound can be compared
+// directly, the same as rounding it down.
+func (search *bnbSearch) cantImprove(bound float64, bestValue int) bool {
+	return bound*(1-search.epsilon) < float64(bestValue)
+}
+
+// Return true if the search should stop.
+// Checking the context is slow, so only do it every 4096 calls.
+func (search *bnbSearch) stopped() bool {
+	if search.ctx == nil || search.cancelled {
+		return search.cancelled
+	}
+	search.calls += 1
+	if search.calls%4096 == 0 && search.ctx.Err() != nil {
+		search.cancelled = true
+	}
+	return search.cancelled
+}
+
+// Return a slice where entry i is the smallest weight of items i and later.
+// The last entry is math.MaxInt because there are no items after the end.
+func suffixMinWeights(items []Item) []int {
+	minWeights := make([]int, len(items)+1)
+	minWeights[len(items)] = math.MaxInt
+	for i := len(items) - 1; i >= 0; i-- {
+		minWeights[i] = min(items[i].weight, minWeights[i+1])
+	}
+	return minWeights
+}
+
+func doBranchAndBound(items []Item, search *bnbSearch, allowedWeight, nextIndex,
+	bestValue, currentValue, currentWeight, remainingValue int,
+) ([]Item, int, int) {
+	if search.stopped() {
+		return nil, 0, 1
+	}
+
+	// See if we have a full assignment.
+	if nextIndex >= len(items) && search.countOnly {
+		return items, solutionValue(items, allowedWeight), 1
+	}
+	if nextIndex >= len(items) {
+		copiedItems := copyItems(items)
+		solutionVal := solutionValue(copiedItems, allowedWeight)
+		if solutionVal > bestValue {
+			bestValue = solutionVal
+			if search.improved != nil {
+				search.improved(copiedItems, solutionVal)
+			}
+		}
+		return copiedItems, solutionVal, 1
+	}
+
+	// We do not have a full assignment.
+	// See if we can improve this solution enough to be worth pursuing.
+	if search.cantImprove(float64(currentValue+remainingValue), bestValue) {
+		// We cannot improve on the best solution found so far.
+		if search.prunes != nil {
+			search.prunes.bound += 1
+		}
+		return nil, 0, 1
+	}
+
+	// The LP relaxation of the remaining items gives a tighter bound.
+	if !search.noLPBound {
+		relaxed, _ := search.lp.bound(nextIndex, allowedWeight-currentWeight)
+		if search.cantImprove(float64(currentValue)+relaxed, bestValue) {
+			if search.prunes != nil {
+				search.prunes.lpBound += 1
+			}
+			return nil, 0, 1
+		}
+	}
+
+	// If none of the remaining items fit, leave them all out
+	// and treat this as a full assignment.
+	if allowedWeight-currentWeight < search.minWeights[nextIndex] {
+		if currentValue <= bestValue {
+			// We already have a solution that is at least this good.
+			if search.prunes != nil {
+				search.prunes.noneFit += 1
+			}
+			return nil, 0, 1
+		}
+		for i := nextIndex; i < len(items); i++ {
+			items[i].isSelected = false
+		}
+		if search.countOnly {
+			return items, currentValue, 1
+		}
+		copiedItems := copyItems(items)
+		if search.improved != nil {
+			search.improved(copiedItems, currentValue)
+		}
+		return copiedItems, currentValue, 1
+	}
+
+	// Try adding the next item.
+	var test1Solution []Item
+	var test1Value int
+	var test1Calls int
+	countConsidered(1)
+	if currentWeight+items[nextIndex].weight <= allowedWeight {
+		items[nextIndex].isSelected = true
+		test1Solution, test1Value, test1Calls = doBranchAndBound(items, search, allowedWeight, nextIndex+1,
+			bestValue, currentValue+items[nextIndex].value, currentWeight+items[nextIndex].weight, remainingValue-items[nextIndex].value)
+		if test1Value > bestValue {
+			bestValue = test1Value
+		}
+	} else {
+		test1Solution = nil
+		test1Value = 0
+		test1Calls = 1
+		if search.prunes != nil {
+			search.prunes.capacity += 1
+		}
+	}
+
+	// Try not adding the next item.
+	var test2Solution []Item
+	var test2Value int
+	var test2Calls int
+	// See if there is a chance of improvement without this item's value.
+	// If every item fits, the include branches reach the full selection
+	// first, bestValue becomes the total value, and this check then prunes
+	// every branch that leaves an item out, so all the items are selected.
+	// TestBranchAndBoundSelectsAllWhenAllFit checks all three variants.
+	if search.noWithoutCheck || currentValue+remainingValue-items[nextIndex].value > bestValue {
+		items[nextIndex].isSelected = false
+		test2Solution, test2Value, test2Calls = doBranchAndBound(items, search, allowedWeight, nextIndex+1,
+			bestValue, currentValue, currentWeight, remainingValue-items[nextIndex].value)
+		if test2Value > bestValue {
+			bestValue = test2Value
+		}
+	} else {
+		test2Solution = nil
+		test2Value = 0
+		test2Calls = 1
+		if search.prunes != nil {
+			search.prunes.withoutItem += 1
+		}
+	}
+
+	// Return the solution that is better. When only counting, both are the
+	// same slice, so compare the values alone.
+	if search.countOnly {
+		if test2Solution == nil || (test1Solution != nil && test1Value >= test2Value) {
+			return test1Solution, test1Value, test1Calls + test2Calls + 1
+		}
+		return test2Solution, test2Value, test1Calls + test2Calls + 1
+	}
+	best := betterSolution(candidate{test1Solution, test1Value, test1Calls}, candidate{test2Solution, test2Value, test2Calls})
+	return best.items, best.value, test1Calls + test2Calls + 1
+}
+
 func rodsTechniqueSorted(items []Item, allowedWeight int) ([]Item, int, int) {
 	makeBlockLists(items)
 
 	// Sort so items with longer blocked lists come first.
-	sort.Slice(items, func(i, j int) bool {
-		return len(items[i].blockList) > len(items[j].blockList)
+	// Break ties by value/weight ratio and then id so the order,
+	// and so the number of calls, is the same every time.
+	// order[k] is the position the k-th sorted item came from.
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := items[order[i]], items[order[j]]
+		if len(a.blockList) != len(b.blockList) {
+			return len(a.blockList) > len(b.blockList)
+		}
+		if a.value*b.weight != b.value*a.weight {
+			return a.value*b.weight > b.value*a.weight
+		}
+		return a.id < b.id
 	})
 
-	// Reset the items' IDs.
-	for i := range items {
-		items[i].id = i
+	// Reset the items' IDs, remembering the original ones.
+	sorted := make([]Item, len(items))
+	originalIDs := make([]int, len(items))
+	for k, i := range order {
+		sorted[k] = items[i]
+		originalIDs[k] = items[i].id
+		sorted[k].id = k
 	}
+	items = sorted
 
 	// Rebuild the blocked lists with the new indices.
 	makeBlockLists(items)
@@ -225,8 +1376,26 @@ func rodsTechniqueSorted(items []Item, allowedWeight int) ([]Item, int, int) {
 	currentWeight := 0
 	remainingValue := sumValues(items, true)
 
-	return doRodsTechnique(items, allowedWeight, 0,
+	solution, totalValue, functionCalls := doRodsTechnique(items, allowedWeight, 0,
 		bestValue, currentValue, currentWeight, remainingValue)
+	if solution != nil {
+		// Report the solution in terms of the items as they came in.
+		solution = restoreOrder(solution, order, originalIDs)
+	}
+	return solution, totalValue, functionCalls
+}
+
+// Put the sorted items back in the positions they came from, given by
+// order, with their original ids. This doesn't rely on the ids being in
+// any order, so it holds however the caller numbered the items.
+// The block lists still use the sorted ids, so don't search the result.
+func restoreOrder(sorted []Item, order, originalIDs []int) []Item {
+	restored := make([]Item, len(sorted))
+	for k, i := range order {
+		restored[i] = sorted[k]
+		restored[i].id = originalIDs[k]
+	}
+	return restored
 }
 
 func doRodsTechnique(items []Item, allowedWeight, nextIndex,
@@ -254,6 +1423,10 @@ func doRodsTechnique(items []Item, allowedWeight, nextIndex,
 	test1Solution = nil
 	test1Value := 0
 	test1Calls := 1
+	countConsidered(1)
+	if items[nextIndex].blockedBy >= 0 {
+		countDominated()
+	}
 	if currentWeight+items[nextIndex].weight <= allowedWeight && items[nextIndex].blockedBy < 0 {
 		items[nextIndex].isSelected = true
 		test1Solution, test1Value, test1Calls = doRodsTechnique(items, allowedWeight, nextIndex+1,
@@ -273,8 +1446,10 @@ func doRodsTechnique(items []Item, allowedWeight, nextIndex,
 		bestValue = test2Value
 	}
 
-	// Return the solution that is better.
-	if test1Value >= test2Value {
+	// Return the solution that is better. A pruned branch has value 0 too,
+	// so when nothing is worth anything, as with a capacity of 0, don't let
+	// it win the tie and leave no solution at all.
+	if test1Solution != nil && test1Value >= test2Value || test2Solution == nil {
 		return test1Solution, test1Value, test1Calls + test2Calls + 1
 	} else {
 		return test2Solution, test2Value, test1Calls + test2Calls + 1
@@ -284,8 +1459,104 @@ func doRodsTechnique(items []Item, allowedWeight, nextIndex,
 // Use dynamic programming to find a solution.
 // Return the best assignment, value of that assignment,
 // and the number of function calls we made.
+// The table has one column per unit of weight, so unlike the generic
+// solvers in cmd/generic-items this only works with integer weights.
 func dynamicProgramming(items []Item, allowedWeight int) ([]Item, int, int) {
 	numItems := len(items)
+	allowedWeight = tableWeight(items, allowedWeight)
+	solutionValue, prevWeight := fillDynamicProgrammingTable(items, allowedWeight)
+	countConsidered(numItems)
+
+	// Reconstruct the solution.
+	// Get the row and column for the final solution.
+	i := numItems - 1
+	w := allowedWeight
+
+	// Work backwards until we reach an initial solution.
+	for i >= 0 {
+		// Check prevWeight for the current solution.
+		prevW := prevWeight[i][w]
+		if !addedItem(items[i], prevW, w) {
+			// We skipped item i.
+			// Leave w unchanged.
+		} else {
+			// We added item i.
+			items[i].isSelected = true // Select this item in the solution.
+			w = prevW                  // Move to the previous solution's weight.
+		}
+		i -= 1 // Move to the previous row.
+	}
+
+	return items, solutionValue[numItems-1][allowedWeight], 1
+}
+
+// Return the largest weight the dynamic programming table needs a column
+// for: the allowed weight, or the items' total weight if that's smaller,
+// since no selection weighs more. A large allowed weight with light
+// items would otherwise allocate columns that can never be reached.
+func tableWeight(items []Item, allowedWeight int) int {
+	return min(allowedWeight, sumWeights(items, true))
+}
+
+// Return true if the solution that reached weight w in item i's row
+// added item i, given that row's prevWeight entry.
+// Adding a weightless item leaves the weight unchanged, just like
+// skipping an item, but the table adds it exactly when it's worth something.
+func addedItem(item Item, prevW, w int) bool {
+	if item.weight == 0 {
+		return item.value > 0
+	}
+	return prevW != w
+}
+
+// Check a solution from dynamicProgramming against freshly filled tables
+// for its items. This fills the tables again, so -check-dp asks for it.
+func checkDynamicProgramming(solution []Item, allowedWeight int) error {
+	width := tableWeight(solution, allowedWeight)
+	solutionValue, prevWeight := fillDynamicProgrammingTable(solution, width)
+	return checkReconstruction(solution, width, solutionValue, prevWeight)
+}
+
+// Check that the items dynamicProgramming selected agree with its tables:
+// they weigh what following prevWeight from the final cell implies and
+// are worth the final cell's value. A mistake in the back-pointer logic
+// could select the wrong items while still reporting the right value.
+func checkReconstruction(items []Item, allowedWeight int, solutionValue, prevWeight [][]int) error {
+	numItems := len(items)
+
+	// Follow prevWeight, adding up the weight each step drops.
+	impliedWeight := 0
+	w := allowedWeight
+	for i := numItems - 1; i >= 0; i-- {
+		prevW := prevWeight[i][w]
+		if !addedItem(items[i], prevW, w) {
+			continue
+		}
+		if prevW < 0 {
+			// Item 0 started the solution.
+			impliedWeight += items[i].weight
+			break
+		}
+		impliedWeight += w - prevW
+		w = prevW
+	}
+
+	if weight := sumWeights(items, false); weight != impliedWeight {
+		return fmt.Errorf("dynamic programming selected weight %d, but prevWeight implies %d", weight, impliedWeight)
+	}
+	if value := sumValues(items, false); value != solutionValue[numItems-1][allowedWeight] {
+		return fmt.Errorf("dynamic programming selected value %d, but the table says %d",
+			value, solutionValue[numItems-1][allowedWeight])
+	}
+	return nil
+}
+
+// Fill in the dynamic programming tables.
+// solutionValue[i][w] is the best value of items 0 through i weighing at most w.
+// prevWeight[i][w] is the weight of the solution that one extends:
+// w if it skipped item i, or -1 if item 0 started it.
+func fillDynamicProgrammingTable(items []Item, allowedWeight int) ([][]int, [][]int) {
+	numItems := len(items)
 
 	// Allocate the arrays.
 	solutionValue := make([][]int, numItems)
@@ -297,12 +1568,14 @@ func dynamicProgramming(items []Item, allowedWeight int) ([]Item, int, int) {
 
 	// Initialize the row item 0.
 	for w := 0; w <= allowedWeight; w++ {
-		if items[0].weight <= w {
-			// items[0] fits.
+		if items[0].weight <= w && items[0].value > 0 {
+			// items[0] fits and is worth taking. Later rows only add an
+			// item that improves the value, so this keeps penalty items
+			// out for callers that don't use withoutNegativeItems.
 			solutionValue[0][w] = items[0].value
 			prevWeight[0][w] = -1
 		} else {
-			// items[0] does not fit.
+			// items[0] does not fit or isn't worth anything.
 			solutionValue[0][w] = 0
 			prevWeight[0][w] = w
 		}
@@ -333,25 +1606,5 @@ func dynamicProgramming(items []Item, allowedWeight int) ([]Item, int, int) {
 		}
 	}
 
-	// Reconstruct the solution.
-	// Get the row and column for the final solution.
-	i := numItems - 1
-	w := allowedWeight
-
-	// Work backwards until we reach an initial solution.
-	for i >= 0 {
-		// Check prevWeight for the current solution.
-		prevW := prevWeight[i][w]
-		if w == prevW {
-			// We skipped item i.
-			// Leave w unchanged.
-		} else {
-			// We added item i.
-			items[i].isSelected = true // Select this item in the solution.
-			w = prevW                  // Move to the previous solution's weight.
-		}
-		i -= 1 // Move to the previous row.
-	}
-
-	return items, solutionValue[numItems-1][allowedWeight], 1
+	return solutionValue, prevWeight
 }
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Return an item with the given id, value and weight, and no group,
+// cost or prerequisites.
+func newItem(id, value, weight int) Item {
+	return Item{id: id, blockedBy: -1, value: value, weight: weight, priority: 1}
+}
+
+func TestDeepCopyItemsIsIndependent(t *testing.T) {
+	items := []Item{newItem(0, 9, 5), newItem(1, 10, 4)}
+	items[0].blockList = []int{1}
+
+	copied := deepCopyItems(items)
+	copied[0].blockList[0] = 0
+	copied[0].blockList = append(copied[0].blockList, 1)
+	copied[1].isSelected = true
+
+	if !slices.Equal(items[0].blockList, []int{1}) {
+		t.Errorf("changing the copy's block list changed the original's to %v", items[0].blockList)
+	}
+	if items[1].isSelected {
+		t.Error("selecting an item in the copy selected it in the original")
+	}
+}
+
+func TestDynamicProgrammingMatchesTables(t *testing.T) {
+	for seed := int64(1); seed <= 100; seed++ {
+		items, allowedWeight := seedInstance(seed, 20)
+		// Add weightless, penalty and overweight items, which the
+		// reconstruction and the filters treat specially.
+		items[0].weight = 0
+		items[1].value = -items[1].value
+		items[2].weight = allowedWeight + 1
+
+		solution := solve("Dynamic programming", dynamicProgramming, items, allowedWeight)
+		requireFeasible(t, items, allowedWeight, solution)
+		if err := checkDynamicProgramming(solution.Items, allowedWeight); err != nil {
+			t.Errorf("seed %d: %v", seed, err)
+		}
+
+		// Swapping a selected item for an unselected one must be caught.
+		for i := range solution.Items {
+			if solution.Items[i].isSelected && solution.Items[i].weight > 0 {
+				solution.Items[i].isSelected = false
+				break
+			}
+		}
+		if err := checkDynamicProgramming(solution.Items, allowedWeight); err == nil {
+			t.Errorf("seed %d: leaving out a selected item wasn't caught", seed)
+		}
+	}
+}
+
+func TestSortingSolversKeepInputOrder(t *testing.T) {
+	// Both solvers search the items in another order, but must return
+	// them in the input's order with the input's ids.
+	items, allowedWeight := seedInstance(7, 20)
+
+	for _, alg := range []Algorithm{
+		{"Rod's technique Sorted", rodsTechniqueSorted},
+		{"Branch and bound", branchAndBound},
+	} {
+		solution, _, _ := alg.Run(deepCopyItems(items), allowedWeight)
+		for i := range items {
+			if solution[i].id != items[i].id || solution[i].value != items[i].value || solution[i].weight != items[i].weight {
+				t.Fatalf("%s: item %d is id %d (%d, %d), want id %d (%d, %d)", alg.Name, i,
+					solution[i].id, solution[i].value, solution[i].weight,
+					items[i].id, items[i].value, items[i].weight)
+			}
+		}
+	}
+}
+
+// Copying makes one allocation of the items' size. The block lists are
+// shared, not copied, so their length doesn't matter; deepCopyItems
+// allocates once more for each list.
+func BenchmarkCopyItems(b *testing.B) {
+	items, _ := seedInstance(1, 40)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		copyItems(items)
+	}
+}
+
+func TestReducedTableWidthChangesNothing(t *testing.T) {
+	for seed := int64(1); seed <= 50; seed++ {
+		items, _ := seedInstance(seed, 15)
+		items[0].weight = 0
+		// Every item fits, with room to spare, so the table is cut
+		// down to the items' total weight.
+		allowedWeight := 3 * sumWeights(items, true)
+
+		solution, value, _ := dynamicProgramming(deepCopyItems(items), allowedWeight)
+
+		// Fill the table at full width and reconstruct from its last column.
+		solutionValue, prevWeight := fillDynamicProgrammingTable(items, allowedWeight)
+		want := Selection{}
+		w := allowedWeight
+		for i := len(items) - 1; i >= 0; i-- {
+			if prevW := prevWeight[i][w]; addedItem(items[i], prevW, w) {
+				want = append(want, items[i].id)
+				w = prevW
+			}
+		}
+		slices.Reverse(want)
+
+		if full := solutionValue[len(items)-1][allowedWeight]; value != full {
+			t.Errorf("seed %d: value %d with the reduced table, %d with the full one", seed, value, full)
+		}
+		if got := selectedIDs(solution); !slices.Equal(got, want) {
+			t.Errorf("seed %d: selected %v with the reduced table, %v with the full one", seed, got, want)
+		}
+	}
+}
+
+func TestTightBoundNeverMakesMoreCalls(t *testing.T) {
+	for seed := int64(1); seed <= 30; seed++ {
+		items, allowedWeight := seedInstance(seed, 20)
+		tight := solve("Branch and bound", branchAndBound, items, allowedWeight)
+		loose := solve("Branch and bound, loose bound", branchAndBoundLooseBound, items, allowedWeight)
+		requireFeasible(t, items, allowedWeight, tight)
+		requireFeasible(t, items, allowedWeight, loose)
+		if tight.Value != loose.Value {
+			t.Errorf("seed %d: value %d with the tight bound, %d with the loose one", seed, tight.Value, loose.Value)
+		}
+		if tight.Calls > loose.Calls {
+			t.Errorf("seed %d: %d calls with the tight bound, more than the loose one's %d", seed, tight.Calls, loose.Calls)
+		}
+	}
+}
+
+// Run branch and bound with each bound on the 40-item instance for
+// seed 1337, reporting the calls alongside the time.
+func BenchmarkLPBound(b *testing.B) {
+	items, allowedWeight := seedInstance(1337, 40)
+	for _, alg := range []Algorithm{
+		{"tight", branchAndBound},
+		{"loose", branchAndBoundLooseBound},
+	} {
+		b.Run(alg.Name, func(b *testing.B) {
+			calls := 0
+			for i := 0; i < b.N; i++ {
+				_, _, calls = alg.Run(copyItems(items), allowedWeight)
+			}
+			b.ReportMetric(float64(calls), "calls/op")
+		})
+	}
+}
+
+func TestResetSelectionClearsARun(t *testing.T) {
+	items, allowedWeight := seedInstance(3, 20)
+	solution, value, _ := rodsTechniqueSorted(items, allowedWeight)
+	blocked := false
+	for _, item := range solution {
+		blocked = blocked || item.blockedBy >= 0
+	}
+	if value == 0 || !blocked {
+		t.Fatalf("Rod's technique left value %d and blockers %t, want both set to test the reset", value, blocked)
+	}
+
+	resetSelection(solution)
+	if total := sumValues(solution, false); total != 0 {
+		t.Errorf("selected value %d after the reset, want 0", total)
+	}
+	for _, item := range solution {
+		if item.blockedBy != -1 {
+			t.Errorf("item %d is blocked by %d after the reset, want -1", item.id, item.blockedBy)
+		}
+	}
+}
+
+// The makeBlockLists comment documents this instance.
+func TestRodsTechniqueIsReproducible(t *testing.T) {
+	first, allowedWeight := seedInstance(1, 30)
+	second, _ := seedInstance(1, 30)
+	makeBlockLists(first)
+	makeBlockLists(second)
+	for i := range first {
+		if !slices.Equal(first[i].blockList, second[i].blockList) {
+			t.Fatalf("item %d blocks %v, then %v", i, first[i].blockList, second[i].blockList)
+		}
+	}
+
+	for run := 0; run < 3; run++ {
+		solution := solve("Rod's technique Sorted", rodsTechniqueSorted, first, allowedWeight)
+		if solution.Calls != 2629 {
+			t.Errorf("run %d: %d calls, want 2,629", run, solution.Calls)
+		}
+	}
+}
+
+func TestRodsTechniqueSortedReportsOriginalIDs(t *testing.T) {
+	for seed := int64(1); seed <= 30; seed++ {
+		items, allowedWeight := seedInstance(seed, 15)
+		// Give each item its own low bit of value, so no two selections
+		// tie and both solvers must pick the same items.
+		for i := range items {
+			items[i].value = items[i].value<<len(items) + 1<<i
+		}
+
+		sorted := solve("Rod's technique Sorted", rodsTechniqueSorted, items, allowedWeight)
+		unsorted := solve("Dynamic programming", dynamicProgramming, items, allowedWeight)
+		requireFeasible(t, items, allowedWeight, sorted)
+		if !sorted.Equal(unsorted) {
+			t.Errorf("seed %d: Rod's technique Sorted selected %v, dynamic programming %v",
+				seed, sorted.SelectedIDs(), unsorted.SelectedIDs())
+		}
+	}
+}
+
+func TestBranchAndBoundSelectsAllWhenAllFit(t *testing.T) {
+	for seed := int64(1); seed <= 500; seed++ {
+		items, _ := seedInstance(seed, 20)
+		allowedWeight := sumWeights(items, true)
+		for _, alg := range []Algorithm{
+			{"Branch and bound", branchAndBound},
+			{"Branch and bound, no without-item check", branchAndBoundNoWithoutCheck},
+			{"Branch and bound, loose bound", branchAndBoundLooseBound},
+		} {
+			solution, value, calls := alg.Run(deepCopyItems(items), allowedWeight)
+			requireFeasible(t, items, allowedWeight, Solution{alg.Name, solution, value, calls, allowedWeight})
+			if want := sumValues(items, true); value != want {
+				t.Errorf("seed %d: %s found value %d, want %d", seed, alg.Name, value, want)
+			}
+			if n := len(selectedIDs(solution)); n != len(items) {
+				t.Errorf("seed %d: %s selected %d of %d items", seed, alg.Name, n, len(items))
+			}
+		}
+	}
+}
+
+func TestZeroCapacitySelectsNothing(t *testing.T) {
+	items, _ := seedInstance(5, 12)
+	names := []string{}
+	for name := range compareAlgorithms {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		alg := compareAlgorithms[name]
+		// Run the solver itself, since solve would filter out every item.
+		found, value, calls := alg.Run(deepCopyItems(items), 0)
+		solution := Solution{alg.Name, found, value, calls, 0}
+		requireFeasible(t, items, 0, solution)
+		if solution.Value != 0 || len(solution.SelectedIDs()) != 0 {
+			t.Errorf("%s: selected %v worth %d with capacity 0, want nothing",
+				name, solution.SelectedIDs(), solution.Value)
+		}
+	}
+}
+
+func TestPrintSelectedByIDSortsByID(t *testing.T) {
+	items, allowedWeight := seedInstance(9, 15)
+	// Give each item its own low bit of value, so both solvers pick the same items.
+	for i := range items {
+		items[i].value = items[i].value<<len(items) + 1<<i
+	}
+	sorted := solve("Rod's technique Sorted", rodsTechniqueSorted, items, allowedWeight)
+	unsorted := solve("Dynamic programming", dynamicProgramming, items, allowedWeight)
+	// Hand the items over in reverse, as a solver that returned them in
+	// its own sorted order would.
+	slices.Reverse(sorted.Items)
+
+	var fromSorted, fromUnsorted strings.Builder
+	printSelectedByID(&fromSorted, sorted.Items)
+	printSelectedByID(&fromUnsorted, unsorted.Items)
+	if fromSorted.String() != fromUnsorted.String() {
+		t.Errorf("the same selection printed differently:\n%s%s", fromSorted.String(), fromUnsorted.String())
+	}
+
+	ids := []int{}
+	// Each item prints as "id(value, weight) ".
+	for _, field := range strings.SplitAfter(strings.TrimSpace(fromSorted.String()), ") ") {
+		id, _, _ := strings.Cut(field, "(")
+		n, err := strconv.Atoi(id)
+		if err != nil {
+			t.Fatalf("can't read the id in %q: %v", field, err)
+		}
+		ids = append(ids, n)
+	}
+	if !slices.Equal(ids, []int(sorted.SelectedIDs())) {
+		t.Errorf("printed ids %v, want %v", ids, sorted.SelectedIDs())
+	}
+}
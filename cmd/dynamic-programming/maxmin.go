@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ppichugin/manning-knapsack-problem/internal/instance"
+)
+
+// Only run maxMinKnapsack if its table has at most this many cells.
+const maxMaxMinCells = 50_000_000
+
+// Return true if any item has a second value.
+func hasValue2(items []Item) bool {
+	for _, item := range items {
+		if item.value2 != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Use dynamic programming to find the selection that maximizes the smaller
+// of its total value and total value2, so neither kind of value is
+// neglected for the other. Items with a negative value or value2 are left
+// out.
+//
+// The table holds the best total value2 for each weight and total value,
+// so it needs (allowedWeight + 1) * (total value + 1) cells per item.
+// Return the selected ids, the smaller total, the totals of value and
+// value2. Return instance.ErrTableTooLarge if the table would have more than
+// maxMaxMinCells cells.
+func maxMinKnapsack(items []Item, allowedWeight int) (Selection, int, int, int, error) {
+	allowedWeight = tableWeight(items, allowedWeight)
+	totalValue := 0
+	for _, item := range items {
+		if item.value >= 0 && item.value2 >= 0 {
+			totalValue += item.value
+		}
+	}
+	width := totalValue + 1
+	cells := (allowedWeight + 1) * width
+	if cells*len(items) > maxMaxMinCells {
+		return nil, 0, 0, 0, fmt.Errorf("%w: max-min knapsack needs %d cells, more than %d",
+			instance.ErrTableTooLarge, cells*len(items), maxMaxMinCells)
+	}
+
+	// best2[w*width+v] is the best total value2 of the items so far weighing
+	// at most w with total value exactly v.
+	const unreachable = math.MinInt
+	best2 := make([]int, cells)
+	for w := 0; w <= allowedWeight; w++ {
+		for v := 1; v < width; v++ {
+			best2[w*width+v] = unreachable
+		}
+	}
+
+	// took[i][w*width+v] is true if item i improved best2[w*width+v].
+	took := make([][]bool, len(items))
+	for i, item := range items {
+		took[i] = make([]bool, cells)
+		if item.value < 0 || item.value2 < 0 {
+			continue
+		}
+
+		// Work downwards so item i is only used once.
+		for w := allowedWeight; w >= item.weight; w-- {
+			for v := totalValue; v >= item.value; v-- {
+				prev := best2[(w-item.weight)*width+v-item.value]
+				if prev != unreachable && prev+item.value2 > best2[w*width+v] {
+					best2[w*width+v] = prev + item.value2
+					took[i][w*width+v] = true
+				}
+			}
+		}
+	}
+
+	// Find the total value that gives the best minimum.
+	bestMin, bestV := -1, 0
+	for v := 0; v < width; v++ {
+		if value2 := best2[allowedWeight*width+v]; value2 != unreachable && min(v, value2) > bestMin {
+			bestMin, bestV = min(v, value2), v
+		}
+	}
+
+	// Work backwards to find the items.
+	selection := Selection{}
+	value2 := best2[allowedWeight*width+bestV]
+	w, v := allowedWeight, bestV
+	for i := len(items) - 1; i >= 0; i-- {
+		if took[i][w*width+v] {
+			selection = append(selection, items[i].id)
+			w -= items[i].weight
+			v -= items[i].value
+		}
+	}
+	for l, r := 0, len(selection)-1; l < r; l, r = l+1, r-1 {
+		selection[l], selection[r] = selection[r], selection[l]
+	}
+	return selection, bestMin, bestV, value2, nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMaxMinBalancesValues(t *testing.T) {
+	// Item 0 is worth the most value but no value2.
+	items := []Item{newItem(0, 10, 5), newItem(1, 4, 5), newItem(2, 4, 5)}
+	items[1].value2 = 4
+	items[2].value2 = 4
+
+	byValue := solve("Dynamic programming", dynamicProgramming, items, 10)
+	if got := selectedIDs(byValue.Items); !slices.Contains(got, 0) {
+		t.Fatalf("dynamic programming selected %v, want item 0 among them", got)
+	}
+
+	selection, smaller, value, value2, err := maxMinKnapsack(items, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(selection, Selection{1, 2}) || smaller != 8 || value != 8 || value2 != 8 {
+		t.Errorf("selected %v with min %d of %d and %d, want [1 2] with min 8 of 8 and 8",
+			selection, smaller, value, value2)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import "sync"
+
+type memoKey struct{ index, weight int }
+
+// A memoCache remembers the best value that items 0 through index can
+// reach within a weight.
+//
+// Use a mapCache when one goroutine owns the cache. It is the fastest.
+// A worker in a pool can keep one mapCache and reset it between instances
+// instead of allocating a new one for each.
+// Use a lockedCache when several goroutines share one cache while solving
+// the same items. Its lock slows even a single goroutine down, as
+// BenchmarkMemoized shows, so only pay for it when the cache really
+// is shared.
+//
+// A cache only holds values for one set of items and allowed weight.
+// Reset it before solving different items.
+type memoCache interface {
+	get(key memoKey) (int, bool)
+	put(key memoKey, value int)
+	reset()
+}
+
+// A memoCache that is not safe for concurrent use.
+type mapCache struct {
+	values map[memoKey]int
+}
+
+func newMapCache() *mapCache {
+	return &mapCache{make(map[memoKey]int)}
+}
+
+func (c *mapCache) get(key memoKey) (int, bool) {
+	value, ok := c.values[key]
+	return value, ok
+}
+
+func (c *mapCache) put(key memoKey, value int) {
+	c.values[key] = value
+}
+
+func (c *mapCache) reset() {
+	clear(c.values)
+}
+
+// A memoCache that is safe for concurrent use.
+type lockedCache struct {
+	mu     sync.RWMutex
+	values map[memoKey]int
+}
+
+func newLockedCache() *lockedCache {
+	return &lockedCache{values: make(map[memoKey]int)}
+}
+
+func (c *lockedCache) get(key memoKey) (int, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	value, ok := c.values[key]
+	return value, ok
+}
+
+func (c *lockedCache) put(key memoKey, value int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+}
+
+func (c *lockedCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.values)
+}
+
+// Use top-down dynamic programming with a fresh cache to find a solution.
+// Return the best assignment, value of that assignment,
+// and the number of function calls we made.
+func memoizedDynamicProgramming(items []Item, allowedWeight int) ([]Item, int, int) {
+	return solveMemoized(items, allowedWeight, newMapCache())
+}
+
+// Use top-down dynamic programming, remembering values in the cache.
+// The items aren't changed, so goroutines can share them and,
+// with a lockedCache, the cache too.
+func solveMemoized(items []Item, allowedWeight int, cache memoCache) ([]Item, int, int) {
+	calls := 0
+
+	// Return the best value that items 0 through i can reach within weight w.
+	var best func(i, w int) int
+	best = func(i, w int) int {
+		if i < 0 {
+			return 0
+		}
+		key := memoKey{i, w}
+		if value, ok := cache.get(key); ok {
+			return value
+		}
+		calls += 1
+
+		value := best(i-1, w)
+		if items[i].weight <= w {
+			value = max(value, best(i-1, w-items[i].weight)+items[i].value)
+		}
+		cache.put(key, value)
+		return value
+	}
+
+	totalValue := best(len(items)-1, allowedWeight)
+
+	// Work backwards. Item i was used if leaving it out is worse.
+	solution := copyItems(items)
+	w := allowedWeight
+	for i := len(items) - 1; i >= 0; i-- {
+		if best(i, w) != best(i-1, w) {
+			solution[i].isSelected = true
+			w -= items[i].weight
+		}
+	}
+	return solution, totalValue, calls
+}
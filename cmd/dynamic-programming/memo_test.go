@@ -0,0 +1,51 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+// Compare the unsynchronized and locked caches on one goroutine,
+// which is what the lock costs when the cache isn't shared.
+func BenchmarkMemoized(b *testing.B) {
+	items, allowedWeight := seedInstance(1, 200)
+	for _, bench := range []struct {
+		name  string
+		cache memoCache
+	}{
+		{"map", newMapCache()},
+		{"locked", newLockedCache()},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				bench.cache.reset()
+				solveMemoized(items, allowedWeight, bench.cache)
+			}
+		})
+	}
+}
+
+func TestLockedCacheShared(t *testing.T) {
+	items, allowedWeight := seedInstance(1, 60)
+	_, want, _ := dynamicProgramming(copyItems(items), allowedWeight)
+
+	cache := newLockedCache()
+	var wg sync.WaitGroup
+	solutions := make([]Solution, 8)
+	for g := range solutions {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			solution, value, calls := solveMemoized(items, allowedWeight, cache)
+			solutions[g] = Solution{"Memoized dynamic programming", solution, value, calls, allowedWeight}
+		}(g)
+	}
+	wg.Wait()
+
+	for g, solution := range solutions {
+		requireFeasible(t, items, allowedWeight, solution)
+		if solution.Value != want {
+			t.Errorf("goroutine %d found %d, want %d", g, solution.Value, want)
+		}
+	}
+}
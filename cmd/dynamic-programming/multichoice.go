@@ -0,0 +1,105 @@
+package main
+
+import "sort"
+
+// Return true if any item belongs to a group.
+func hasGroups(items []Item) bool {
+	for _, item := range items {
+		if item.group != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Return the item indices split into groups, in order of first appearance.
+// Each item without a group gets a group of its own.
+func groupIndices(items []Item) [][]int {
+	groups := [][]int{}
+	position := make(map[int]int)
+	for i, item := range items {
+		if item.group == 0 {
+			groups = append(groups, []int{i})
+			continue
+		}
+		p, ok := position[item.group]
+		if !ok {
+			p = len(groups)
+			position[item.group] = p
+			groups = append(groups, []int{})
+		}
+		groups[p] = append(groups[p], i)
+	}
+	return groups
+}
+
+// Use dynamic programming to solve the multiple-choice knapsack problem,
+// where at most one item from each group may be selected.
+// This is what configuration problems need: pick one CPU, one RAM module.
+//
+// The groups are handled one at a time. For each weight, the best value
+// either skips the group or adds one of its items to the best value of
+// the earlier groups.
+// Return the assignment, value of that assignment,
+// and the number of cells in the table.
+func multipleChoiceKnapsack(items []Item, allowedWeight int) ([]Item, int, int) {
+	groups := groupIndices(items)
+	numGroups := len(groups)
+
+	// bestValue[g][w] is the best value of groups 0 through g-1 weighing at most w.
+	// chosen[g][w] is the item picked from group g-1 for that value, or -1.
+	bestValue := make([][]int, numGroups+1)
+	chosen := make([][]int, numGroups+1)
+	bestValue[0] = make([]int, allowedWeight+1)
+	for g := 1; g <= numGroups; g++ {
+		bestValue[g] = make([]int, allowedWeight+1)
+		chosen[g] = make([]int, allowedWeight+1)
+		for w := 0; w <= allowedWeight; w++ {
+			bestValue[g][w] = bestValue[g-1][w]
+			chosen[g][w] = -1
+			for _, i := range groups[g-1] {
+				if items[i].weight > w {
+					continue
+				}
+				value := bestValue[g-1][w-items[i].weight] + items[i].value
+				if value > bestValue[g][w] {
+					bestValue[g][w] = value
+					chosen[g][w] = i
+				}
+			}
+		}
+	}
+
+	// Work backwards to find the item picked from each group.
+	w := allowedWeight
+	for g := numGroups; g > 0; g-- {
+		if i := chosen[g][w]; i >= 0 {
+			items[i].isSelected = true
+			w -= items[i].weight
+		}
+	}
+
+	return items, bestValue[numGroups][allowedWeight], (numGroups + 1) * (allowedWeight + 1)
+}
+
+// Return the id of the selected item in each group, keyed by group.
+// Items without a group aren't included.
+func groupChoices(items []Item) map[int]int {
+	choices := make(map[int]int)
+	for _, item := range items {
+		if item.isSelected && item.group != 0 {
+			choices[item.group] = item.id
+		}
+	}
+	return choices
+}
+
+// Return the groups with a selected item in increasing order.
+func chosenGroups(choices map[int]int) []int {
+	groups := make([]int, 0, len(choices))
+	for group := range choices {
+		groups = append(groups, group)
+	}
+	sort.Ints(groups)
+	return groups
+}
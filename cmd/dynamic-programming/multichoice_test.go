@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestMultipleChoiceTakesOnePerGroup(t *testing.T) {
+	// Items 0 and 1 are the best pair, but they're in the same group.
+	items := []Item{newItem(0, 6, 3), newItem(1, 6, 3), newItem(2, 4, 3)}
+	items[0].group = 1
+	items[1].group = 1
+
+	naive := solve("Dynamic programming", dynamicProgramming, items, 6)
+	requireFeasible(t, items, 6, naive)
+	if naive.Value != 12 {
+		t.Fatalf("dynamic programming found %d, want 12 from both grouped items", naive.Value)
+	}
+
+	solution := solve("Multiple-choice knapsack", multipleChoiceKnapsack, items, 6)
+	requireFeasible(t, items, 6, solution)
+	if solution.Value != 10 {
+		t.Errorf("multiple-choice value %d, want 10", solution.Value)
+	}
+	if solution.Items[0].isSelected && solution.Items[1].isSelected {
+		t.Error("multiple-choice selected both items from group 1")
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ppichugin/manning-knapsack-problem/internal/instance"
+)
+
+// Parse a comma-separated list of knapsack capacities like "30,40".
+func parseCapacities(list string) ([]int, error) {
+	capacities := []int{}
+	for _, field := range strings.Split(list, ",") {
+		capacity, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("bad capacity %q: %w", field, err)
+		}
+		if capacity < 0 {
+			return nil, fmt.Errorf("%w: %d", instance.ErrNegativeCapacity, capacity)
+		}
+		capacities = append(capacities, capacity)
+	}
+	return capacities, nil
+}
+
+// Use branch and bound to pack the items into several knapsacks, one
+// capacity each, so their total value is as large as possible.
+// Each item goes into at most one knapsack.
+//
+// Each step puts the next item into one of the knapsacks it fits in or
+// leaves it out. Branches are pruned when the LP relaxation of the
+// remaining items, with all of the remaining capacity pooled together,
+// can't beat the best packing so far. Knapsacks with the same remaining
+// capacity are interchangeable, so only the first of them is tried.
+// Return the knapsack index for each item, or -1 if it was left out,
+// and the total value.
+func multiKnapsack(items []Item, capacities []int) ([]int, int) {
+	search := &multiSearch{
+		items:      items,
+		order:      ratioOrder(items),
+		remaining:  append([]int{}, capacities...),
+		assignment: make([]int, len(items)),
+		best:       make([]int, len(items)),
+	}
+	for i := range search.best {
+		search.best[i] = -1
+	}
+	search.place(0, 0)
+	return search.best, search.bestValue
+}
+
+// The state of a multiKnapsack search.
+type multiSearch struct {
+	items      []Item
+	order      []int // The item indices in decreasing value/weight order.
+	remaining  []int // The capacity left in each knapsack.
+	assignment []int // The knapsack for each item so far, or -1.
+	best       []int // The best assignment found so far.
+	bestValue  int
+}
+
+// Decide where items nextIndex and later go, given the value so far.
+func (search *multiSearch) place(nextIndex, currentValue int) {
+	if currentValue > search.bestValue {
+		search.bestValue = currentValue
+		copy(search.best, search.assignment)
+		for i := nextIndex; i < len(search.best); i++ {
+			search.best[i] = -1
+		}
+	}
+	if nextIndex >= len(search.items) {
+		return
+	}
+
+	// See if the remaining items could beat the best packing.
+	pooled := 0
+	for _, capacity := range search.remaining {
+		pooled += capacity
+	}
+	relaxed, _ := relaxedValue(search.items, search.order, nextIndex, pooled)
+	if currentValue+int(relaxed) <= search.bestValue {
+		return
+	}
+
+	// Try putting the item in each knapsack that it fits in.
+	item := search.items[nextIndex]
+	if item.value > 0 {
+		for k, capacity := range search.remaining {
+			if item.weight > capacity || search.triedSameCapacity(k) {
+				continue
+			}
+			search.remaining[k] -= item.weight
+			search.assignment[nextIndex] = k
+			search.place(nextIndex+1, currentValue+item.value)
+			search.remaining[k] += item.weight
+		}
+	}
+
+	// Try leaving the item out.
+	search.assignment[nextIndex] = -1
+	search.place(nextIndex+1, currentValue)
+}
+
+// Return true if a knapsack before k has the same remaining capacity,
+// so trying k would only repeat that branch.
+func (search *multiSearch) triedSameCapacity(k int) bool {
+	for j := 0; j < k; j++ {
+		if search.remaining[j] == search.remaining[k] {
+			return true
+		}
+	}
+	return false
+}
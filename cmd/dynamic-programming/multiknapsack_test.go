@@ -0,0 +1,50 @@
+package main
+
+import "testing"
+
+func TestMultiKnapsackMatchesBruteForce(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		items, allowedWeight := seedInstance(seed, 10)
+		capacities := []int{allowedWeight / 3, allowedWeight - allowedWeight/3}
+
+		// Try every way to leave out each item or put it in one of the two knapsacks.
+		best := 0
+		var try func(i int, remaining []int, value int)
+		try = func(i int, remaining []int, value int) {
+			if i == len(items) {
+				best = max(best, value)
+				return
+			}
+			try(i+1, remaining, value)
+			for k := range remaining {
+				if items[i].weight <= remaining[k] {
+					remaining[k] -= items[i].weight
+					try(i+1, remaining, value+items[i].value)
+					remaining[k] += items[i].weight
+				}
+			}
+		}
+		try(0, append([]int{}, capacities...), 0)
+
+		assignment, value := multiKnapsack(items, capacities)
+		if value != best {
+			t.Errorf("seed %d: packed %d, want %d", seed, value, best)
+		}
+		packedValue := 0
+		packedWeights := make([]int, len(capacities))
+		for i, k := range assignment {
+			if k >= 0 {
+				packedValue += items[i].value
+				packedWeights[k] += items[i].weight
+			}
+		}
+		if packedValue != value {
+			t.Errorf("seed %d: the packed items are worth %d, not the %d returned", seed, packedValue, value)
+		}
+		for k, weight := range packedWeights {
+			if weight > capacities[k] {
+				t.Errorf("seed %d: knapsack %d holds weight %d, more than its capacity %d", seed, k, weight, capacities[k])
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+// The most items countNearOptimal will enumerate.
+const maxNearOptimalItems = 30
+
+// Count the feasible selections worth at least fraction times the best
+// possible value. Many near-optimal selections make a smooth landscape
+// that local search handles easily; few make a rugged, harder one.
+// This enumerates selections, so it returns -1 if there are more than
+// maxNearOptimalItems items.
+func countNearOptimal(items []Item, capacity int, fraction float64) int {
+	if len(items) > maxNearOptimalItems {
+		return -1
+	}
+	_, bestValue, _ := dynamicProgramming(copyItems(items), capacity)
+	target := fraction * float64(bestValue)
+	count := 0
+	WalkSolutions(items, capacity, func(_ Selection, value, _ int) bool {
+		if float64(value) >= target {
+			count++
+		}
+		return true
+	})
+	return count
+}
@@ -0,0 +1,16 @@
+package main
+
+// Wrap an algorithm so it runs without the items that have negative values.
+// A penalty item never belongs in the best solution, and several
+// algorithms assume values aren't negative: the bounds in branch and bound
+// and Rod's technique, greedy, and the FPTAS's scaled values.
+// The remaining items are renumbered, prerequisites included, and the
+// solution is mapped back onto the original items by position.
+func withoutNegativeItems(alg func([]Item, int) ([]Item, int, int)) func([]Item, int) ([]Item, int, int) {
+	return withItemFilter(nonNegativeValue, alg)
+}
+
+// Return true if the item's value isn't negative.
+func nonNegativeValue(item Item) bool {
+	return item.value >= 0
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNegativeItemsNeverSelected(t *testing.T) {
+	for seed := int64(1); seed <= 50; seed++ {
+		items, allowedWeight := seedInstance(seed, 12)
+		for i := 0; i < len(items); i += 3 {
+			items[i].value = -items[i].value
+		}
+
+		for name, alg := range compareAlgorithms {
+			solution := solve(alg.Name, alg.Run, items, allowedWeight)
+			requireFeasible(t, items, allowedWeight, solution)
+			for _, item := range solution.Items {
+				if item.isSelected && item.value < 0 {
+					t.Errorf("seed %d: %s selected item %d worth %d", seed, name, item.id, item.value)
+				}
+			}
+		}
+	}
+}
+
+func TestWithoutNegativeItemsRenumbersPrerequisites(t *testing.T) {
+	// Item 2 requires item 1, which moves to position 0 once item 0 is dropped.
+	items := []Item{newItem(0, -2, 1), newItem(1, 5, 2), newItem(2, 4, 2)}
+	items[2].requires = []int{1}
+
+	var seen []Item
+	alg := withoutNegativeItems(func(items []Item, allowedWeight int) ([]Item, int, int) {
+		seen = copyItems(items)
+		return exhaustiveSearch(items, allowedWeight)
+	})
+	solution, value, _ := alg(deepCopyItems(items), 4)
+
+	if len(seen) != 2 || seen[1].value != 4 || !slices.Equal(seen[1].requires, []int{0}) {
+		t.Fatalf("the algorithm saw %+v, want item 2 at position 1 requiring position 0", seen)
+	}
+	if value != 9 || !solution[1].isSelected || !solution[2].isSelected || solution[0].isSelected {
+		t.Errorf("value %d with selection %v, want 9 with items 1 and 2", value, selectedIDs(solution))
+	}
+}
+
+func TestNegativeItemsInRankingAndNearOptimal(t *testing.T) {
+	// The penalty item comes first, where the table's first row starts.
+	items := []Item{newItem(0, -5, 1), newItem(1, 3, 1)}
+
+	var out strings.Builder
+	printRanking(&out, items, 2)
+	for _, line := range strings.Split(out.String(), "\n") {
+		if strings.HasPrefix(line, "2,0,-5,") && !strings.HasSuffix(line, ",false") {
+			t.Errorf("ranking selected the penalty item: %s", line)
+		}
+	}
+	if !strings.Contains(out.String(), "1,1,3,1,3.000000,true\n") {
+		t.Errorf("ranking didn't select item 1:\n%s", out.String())
+	}
+
+	// Only item 1 alone is worth at least half of the optimum, 3.
+	if count := countNearOptimal(items, 2, 0.5); count != 1 {
+		t.Errorf("counted %d near-optimal selections, want 1", count)
+	}
+
+	if _, value, _ := dynamicProgramming(deepCopyItems(items), 2); value != 3 {
+		t.Errorf("dynamic programming found value %d without withoutNegativeItems, want 3", value)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+// The number of leading items whose choices are split across goroutines.
+const parallelSplitDepth = 4
+
+// Use exhaustive search, with one goroutine for each way to pick
+// the first few items, to find a solution.
+// The branches are merged with betterSolutionBy, so its output is
+// reproducible even though the goroutines finish in any order.
+// Ties break as exhaustiveSearch breaks them.
+// Return the best assignment, value of that assignment,
+// and the number of function calls we made.
+func parallelExhaustiveSearch(items []Item, allowedWeight int) ([]Item, int, int) {
+	return doParallelExhaustiveSearch(items, allowedWeight, preferCanonical)
+}
+
+// Return a parallel exhaustive search that breaks value ties by the preference.
+func parallelExhaustiveSearchPreferring(prefer tiePreference) func([]Item, int) ([]Item, int, int) {
+	return func(items []Item, allowedWeight int) ([]Item, int, int) {
+		return doParallelExhaustiveSearch(items, allowedWeight, prefer)
+	}
+}
+
+func doParallelExhaustiveSearch(items []Item, allowedWeight int, prefer tiePreference) ([]Item, int, int) {
+	depth := min(parallelSplitDepth, len(items))
+	numBranches := 1 << depth
+
+	results := make(chan candidate)
+	for branch := 0; branch < numBranches; branch++ {
+		go func(branch int) {
+			branchItems := copyItems(items)
+			for i := 0; i < depth; i++ {
+				branchItems[i].isSelected = branch&(1<<i) != 0
+			}
+			solution, value, calls := doExhaustiveSearch(branchItems, allowedWeight, depth, prefer)
+			results <- candidate{solution, value, calls}
+		}(branch)
+	}
+
+	// The branches account for all but the calls that picked the first items.
+	best := <-results
+	calls := best.calls + numBranches - 1
+	for k := 1; k < numBranches; k++ {
+		result := <-results
+		calls += result.calls
+		best = betterSolutionBy(best, result, prefer)
+	}
+	return best.items, best.value, calls
+}
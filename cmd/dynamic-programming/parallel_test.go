@@ -0,0 +1,25 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParallelExhaustiveSearchIsReproducible(t *testing.T) {
+	// Identical items make many equally good selections to choose from.
+	items := make([]Item, 14)
+	for i := range items {
+		items[i] = newItem(i, 3, 2)
+	}
+	const allowedWeight = 13
+
+	want := solve("Exhaustive search", exhaustiveSearch, items, allowedWeight)
+	for run := 0; run < 50; run++ {
+		got := solve("Parallel exhaustive search", parallelExhaustiveSearch, items, allowedWeight)
+		requireFeasible(t, items, allowedWeight, got)
+		if !slices.Equal(got.SelectedIDs(), want.SelectedIDs()) {
+			t.Fatalf("run %d selected %v, want %v as exhaustive search does",
+				run, got.SelectedIDs(), want.SelectedIDs())
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// A point on the pareto frontier: the weight and value of a packing
+// that nothing lighter or as heavy beats.
+type paretoPoint struct {
+	Weight, Value int
+}
+
+// Return the pareto frontier of all packings of the items: the (weight, value)
+// pairs that no other packing beats by weighing no more and being worth more.
+// For any capacity, the best value is that of the last point that fits,
+// so this answers every capacity in one computation.
+//
+// The items are added one at a time. Each adds its weight and value to every
+// point found so far, and the points that the result dominates are dropped.
+// Items with negative values are never worth adding.
+// Return the frontier sorted by weight, so each point is worth strictly
+// more than the one before.
+func paretoFrontier(items []Item) []paretoPoint {
+	frontier := []paretoPoint{{0, 0}}
+	for _, item := range items {
+		if item.value < 0 {
+			continue
+		}
+		candidates := make([]paretoPoint, 0, 2*len(frontier))
+		candidates = append(candidates, frontier...)
+		for _, p := range frontier {
+			candidates = append(candidates, paretoPoint{p.Weight + item.weight, p.Value + item.value})
+		}
+		frontier = nonDominated(candidates)
+	}
+	return frontier
+}
+
+// Return the points that no other point dominates, sorted by weight.
+func nonDominated(points []paretoPoint) []paretoPoint {
+	// Sort lighter first, and more valuable first among equal weights,
+	// so a point survives only if it beats every lighter point.
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Weight != points[j].Weight {
+			return points[i].Weight < points[j].Weight
+		}
+		return points[i].Value > points[j].Value
+	})
+
+	result := points[:0]
+	for _, p := range points {
+		if len(result) == 0 || p.Value > result[len(result)-1].Value {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// Print a CSV line for each point on the items' pareto frontier.
+func printParetoFrontier(items []Item) {
+	fmt.Println("weight,value")
+	for _, p := range paretoFrontier(items) {
+		fmt.Printf("%d,%d\n", p.Weight, p.Value)
+	}
+}
+
+// Return the best values at capacities allowedWeight-delta, allowedWeight,
+// and allowedWeight+delta, which show what a smaller or bigger knapsack
+// would be worth. One pareto frontier answers all three.
+// A capacity below 0 counts as 0.
+func capacitySensitivity(items []Item, allowedWeight, delta int) (int, int, int) {
+	frontier := paretoFrontier(items)
+	bestAt := func(capacity int) int {
+		// Find the last point that fits.
+		k := sort.Search(len(frontier), func(k int) bool { return frontier[k].Weight > capacity })
+		if k == 0 {
+			return 0
+		}
+		return frontier[k-1].Value
+	}
+	return bestAt(max(0, allowedWeight-delta)), bestAt(allowedWeight), bestAt(allowedWeight + delta)
+}
@@ -0,0 +1,50 @@
+package main
+
+import "testing"
+
+func TestParetoFrontierIsStrictlyIncreasing(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		items, _ := seedInstance(seed, 15)
+		frontier := paretoFrontier(items)
+		if len(frontier) == 0 || frontier[0] != (paretoPoint{0, 0}) {
+			t.Fatalf("seed %d: the frontier starts at %v, want the empty packing", seed, frontier)
+		}
+		for k := 1; k < len(frontier); k++ {
+			if frontier[k].Weight <= frontier[k-1].Weight || frontier[k].Value <= frontier[k-1].Value {
+				t.Errorf("seed %d: point %v follows %v", seed, frontier[k], frontier[k-1])
+			}
+		}
+
+		// The last point that fits each capacity is its optimum.
+		for _, capacity := range []int{0, 7, 20, sumWeights(items, true) / 2, sumWeights(items, true)} {
+			best := 0
+			for _, p := range frontier {
+				if p.Weight <= capacity {
+					best = p.Value
+				}
+			}
+			if _, want, _ := dynamicProgramming(copyItems(items), capacity); best != want {
+				t.Errorf("seed %d: the frontier gives %d at capacity %d, dynamic programming %d",
+					seed, best, capacity, want)
+			}
+		}
+	}
+}
+
+func TestCapacitySensitivityIsMonotonic(t *testing.T) {
+	items, allowedWeight := seedInstance(6, 20)
+	previous := 0
+	for capacity := 0; capacity <= 2*allowedWeight; capacity++ {
+		minus, at, plus := capacitySensitivity(items, capacity, 5)
+		if minus > at || at > plus || at < previous {
+			t.Errorf("capacity %d: values %d, %d, %d after %d at capacity %d",
+				capacity, minus, at, plus, previous, capacity-1)
+		}
+		if capacity%10 == 0 {
+			if _, want, _ := dynamicProgramming(copyItems(items), capacity); at != want {
+				t.Errorf("capacity %d: value %d, dynamic programming %d", capacity, at, want)
+			}
+		}
+		previous = at
+	}
+}
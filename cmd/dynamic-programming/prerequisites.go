@@ -0,0 +1,117 @@
+package main
+
+// Return true if any item requires another.
+func hasPrerequisites(items []Item) bool {
+	for _, item := range items {
+		if len(item.requires) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Return true if every selected item's prerequisites are selected too.
+func prerequisitesMet(items []Item) bool {
+	for _, item := range items {
+		if !item.isSelected {
+			continue
+		}
+		for _, r := range item.requires {
+			if !items[r].isSelected {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Use branch and bound to find the best solution in which every selected
+// item's prerequisites are selected too, like a charger that's no use
+// without its device. Prerequisites are item positions.
+//
+// The items are decided in order. An item can only be added if its
+// earlier prerequisites were, and must be added if an earlier selected
+// item requires it. Dynamic programming can't follow arbitrary
+// dependencies, so this searches.
+//
+// Penalty items aren't dropped up front, since taking one can unlock a
+// more valuable item. Run this without withoutNegativeItems.
+// Return the best assignment, value of that assignment,
+// and the number of function calls we made.
+func prerequisiteSearch(items []Item, allowedWeight int) ([]Item, int, int) {
+	// requiredBy[i] lists the items that require item i.
+	requiredBy := make([][]int, len(items))
+	for j, item := range items {
+		for _, r := range item.requires {
+			requiredBy[r] = append(requiredBy[r], j)
+		}
+	}
+
+	// remaining[i] is the value of the non-penalty items i and later,
+	// which bounds what they can add.
+	remaining := make([]int, len(items)+1)
+	for i := len(items) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + max(0, items[i].value)
+	}
+
+	search := prerequisiteState{items, allowedWeight, requiredBy, remaining, nil, -1, 0}
+	search.visit(0, 0, 0)
+
+	solution := copyItems(items)
+	for i := range solution {
+		solution[i].isSelected = search.best != nil && search.best[i]
+	}
+	return solution, max(0, search.bestValue), search.calls
+}
+
+// The state of one prerequisiteSearch.
+type prerequisiteState struct {
+	items         []Item
+	allowedWeight int
+	requiredBy    [][]int
+	remaining     []int
+	best          []bool // The best selection so far, or nil for none.
+	bestValue     int
+	calls         int
+}
+
+// Decide items i and later, given the value and weight of the earlier
+// selected items, which are marked in items.
+func (s *prerequisiteState) visit(i, value, weight int) {
+	s.calls++
+	if weight > s.allowedWeight || value+s.remaining[i] <= s.bestValue {
+		return
+	}
+	if i == len(s.items) {
+		s.bestValue = value
+		s.best = make([]bool, len(s.items))
+		for k := range s.items {
+			s.best[k] = s.items[k].isSelected
+		}
+		return
+	}
+
+	// An earlier selected item may require this one,
+	// and this one may require an earlier item that wasn't selected.
+	forced := false
+	for _, j := range s.requiredBy[i] {
+		if j < i && s.items[j].isSelected {
+			forced = true
+		}
+	}
+	allowed := true
+	for _, r := range s.items[i].requires {
+		if r < i && !s.items[r].isSelected {
+			allowed = false
+		}
+	}
+
+	if allowed {
+		s.items[i].isSelected = true
+		s.visit(i+1, value+s.items[i].value, weight+s.items[i].weight)
+		s.items[i].isSelected = false
+	}
+	if !forced {
+		s.visit(i+1, value, weight)
+	}
+}
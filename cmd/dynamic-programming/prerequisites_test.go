@@ -0,0 +1,44 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPrerequisiteThatDoesNotFit(t *testing.T) {
+	// Item 1 is the most valuable, but it requires item 0, which is too heavy.
+	items := []Item{newItem(0, 1, 9), newItem(1, 20, 2), newItem(2, 5, 3)}
+	items[1].requires = []int{0}
+
+	solution, value, calls := prerequisiteSearch(deepCopyItems(items), 8)
+	requireFeasible(t, items, 8, Solution{"Branch and bound with prerequisites", solution, value, calls, 8})
+	if !prerequisitesMet(solution) {
+		t.Error("a selected item's prerequisite isn't selected")
+	}
+	if got := selectedIDs(solution); value != 5 || !slices.Equal(got, Selection{2}) {
+		t.Errorf("selected %v worth %d, want [2] worth 5", got, value)
+	}
+}
+
+func TestPrerequisitesSurviveShuffling(t *testing.T) {
+	// The charger is worth the most but needs the device.
+	items := []Item{newItem(0, 1, 1), newItem(1, 50, 2), newItem(2, 5, 9), newItem(3, 4, 8)}
+	items[1].requires = []int{0}
+
+	for seed := int64(1); seed <= 20; seed++ {
+		shuffled, perm := shuffleItems(items, seed)
+		solution, value, calls := prerequisiteSearch(deepCopyItems(shuffled), 10)
+		requireFeasible(t, shuffled, 10, Solution{"Branch and bound with prerequisites", solution, value, calls, 10})
+		if !prerequisitesMet(solution) {
+			t.Errorf("seed %d: a selected item's prerequisite isn't selected", seed)
+		}
+		original := Selection{}
+		for _, id := range selectedIDs(solution) {
+			original = append(original, perm[id])
+		}
+		slices.Sort(original)
+		if value != 51 || !slices.Equal(original, Selection{0, 1}) {
+			t.Errorf("seed %d: selected original items %v worth %d, want [0 1] worth 51", seed, original, value)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ppichugin/manning-knapsack-problem/internal/packing"
+)
+
+// Priorities are scaled by this and rounded so dynamic programming can
+// use whole-number values. Priorities closer than 1/prioritySteps apart
+// may be treated as equal.
+const prioritySteps = 100
+
+// Return true if any item has a priority other than 1.
+func hasPriorities(items []Item) bool {
+	for _, item := range items {
+		if item.priority != 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// Return the priority-weighted value of the selected items:
+// the sum of priority times value.
+func prioritizedValue(items []Item) float64 {
+	total := 0.0
+	for _, item := range items {
+		if item.isSelected {
+			total += item.priority * float64(item.value)
+		}
+	}
+	return total
+}
+
+// Wrap an algorithm so it maximizes the priority-weighted value while
+// weights and the allowed weight stay as they are. Each value becomes
+// priority * value * prioritySteps, rounded. The solution is mapped
+// back onto the original items by id, and its value is their real value.
+func withPriorities(alg func([]Item, int) ([]Item, int, int)) func([]Item, int) ([]Item, int, int) {
+	return func(items []Item, allowedWeight int) ([]Item, int, int) {
+		scaled := copyItems(items)
+		for i := range scaled {
+			scaled[i].value = int(math.Round(scaled[i].priority * float64(scaled[i].value) * prioritySteps))
+		}
+
+		solution, _, functionCalls := alg(scaled, allowedWeight)
+		result := copyItems(items)
+		for _, item := range solution {
+			if item.isSelected {
+				result[item.id].isSelected = true
+			}
+		}
+		return result, sumValues(result, false), functionCalls
+	}
+}
+
+// Take items in decreasing priority * value/weight order while they fit.
+// Unlike greedy under withPriorities, the priorities aren't rounded.
+// Return the assignment, value of that assignment,
+// and the number of items we examined.
+func prioritizedGreedy(items []Item, allowedWeight int) ([]Item, int, int) {
+	order := prioritizedOrder(items)
+
+	totalValue := 0
+	totalWeight := 0
+	calls := 0
+	for _, i := range order {
+		calls += 1
+		if totalWeight+items[i].weight <= allowedWeight {
+			items[i].isSelected = true
+			totalValue += items[i].value
+			totalWeight += items[i].weight
+		}
+	}
+	return items, totalValue, calls
+}
+
+// Return the item indices in decreasing priority * value/weight order.
+// Compare as ratioOrder does, with the weighted values.
+func prioritizedOrder(items []Item) []int {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := items[order[i]], items[order[j]]
+		va, vb := a.priority*float64(a.value), b.priority*float64(b.value)
+		if ca, cb := packing.RatioClass(va, a.weight), packing.RatioClass(vb, b.weight); ca != cb {
+			return ca < cb
+		}
+		return va*float64(b.weight) > vb*float64(a.weight)
+	})
+	return order
+}
+
+// Use branch and bound to maximize the priority-weighted value.
+// Unlike branch and bound under withPriorities, the priorities aren't
+// rounded: the search and its LP bound work with the weighted values
+// as floats. Return the best assignment, its real value,
+// and the number of function calls we made.
+func prioritizedBranchAndBound(items []Item, allowedWeight int) ([]Item, int, int) {
+	search := &prioritySearch{
+		items:     items,
+		order:     prioritizedOrder(items),
+		taken:     make([]bool, len(items)),
+		bestSoFar: make([]bool, len(items)),
+	}
+	search.branch(0, allowedWeight, 0)
+	for i := range items {
+		items[i].isSelected = search.bestSoFar[i]
+	}
+	return items, sumValues(items, false), search.calls
+}
+
+// The state of one prioritizedBranchAndBound search.
+type prioritySearch struct {
+	items     []Item
+	order     []int   // The item indices in prioritizedOrder.
+	taken     []bool  // The items taken on the current branch.
+	bestSoFar []bool  // The items taken in the best solution so far.
+	bestValue float64 // Its priority-weighted value.
+	calls     int
+}
+
+// Decide whether to take the k-th item in order, given the weight left
+// and the weighted value of the items taken so far.
+func (search *prioritySearch) branch(k, remaining int, value float64) {
+	search.calls += 1
+	if k == len(search.order) {
+		if value > search.bestValue {
+			search.bestValue = value
+			copy(search.bestSoFar, search.taken)
+		}
+		return
+	}
+	if search.bound(k, remaining, value) <= search.bestValue {
+		return
+	}
+
+	i := search.order[k]
+	item := search.items[i]
+	if item.weight <= remaining {
+		search.taken[i] = true
+		search.branch(k+1, remaining-item.weight, value+item.priority*float64(item.value))
+		search.taken[i] = false
+	}
+	search.branch(k+1, remaining, value)
+}
+
+// Return the LP relaxation of the items from the k-th in order on,
+// plus the value so far: a bound on any branch from here.
+func (search *prioritySearch) bound(k, remaining int, value float64) float64 {
+	for _, i := range search.order[k:] {
+		item := search.items[i]
+		weighted := item.priority * float64(item.value)
+		if weighted <= 0 {
+			continue
+		}
+		if item.weight <= remaining {
+			value += weighted
+			remaining -= item.weight
+		} else {
+			return value + weighted*float64(remaining)/float64(item.weight)
+		}
+	}
+	return value
+}
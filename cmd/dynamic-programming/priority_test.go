@@ -0,0 +1,65 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDoublingPriorityFlipsSelection(t *testing.T) {
+	// Only one item fits. Item 0 is worth more until item 1's priority doubles.
+	items := []Item{newItem(0, 10, 5), newItem(1, 9, 5)}
+
+	plain := solve("Dynamic programming", withPriorities(dynamicProgramming), items, 5)
+	requireFeasible(t, items, 5, plain)
+	if !plain.Items[0].isSelected || plain.Items[1].isSelected {
+		t.Fatalf("with equal priorities selected %v, want [0]", selectedIDs(plain.Items))
+	}
+
+	items[1].priority = 2
+	for _, alg := range []Algorithm{
+		{"Dynamic programming, by priority", withPriorities(dynamicProgramming)},
+		{"Branch and bound, by priority", prioritizedBranchAndBound},
+		{"Greedy, by priority", prioritizedGreedy},
+	} {
+		solution := solve(alg.Name, alg.Run, items, 5)
+		requireFeasible(t, items, 5, solution)
+		if solution.Items[0].isSelected || !solution.Items[1].isSelected {
+			t.Errorf("%s selected %v, want [1]", alg.Name, selectedIDs(solution.Items))
+		}
+		if got := prioritizedValue(solution.Items); got != 18 {
+			t.Errorf("%s: prioritized value %g, want 18", alg.Name, got)
+		}
+	}
+}
+
+func TestPrioritizedBranchAndBoundIsOptimal(t *testing.T) {
+	for seed := int64(1); seed <= 30; seed++ {
+		items, allowedWeight := seedInstance(seed, 12)
+		random := rand.New(rand.NewSource(seed))
+		for i := range items {
+			items[i].priority = 2 * random.Float64()
+		}
+
+		// Try every subset for the best priority-weighted value.
+		best := 0.0
+		for mask := 0; mask < 1<<len(items); mask++ {
+			weight, value := 0, 0.0
+			for i, item := range items {
+				if mask&(1<<i) != 0 {
+					weight += item.weight
+					value += item.priority * float64(item.value)
+				}
+			}
+			if weight <= allowedWeight {
+				best = max(best, value)
+			}
+		}
+
+		solution := solve("Branch and bound, by priority", prioritizedBranchAndBound, items, allowedWeight)
+		requireFeasible(t, items, allowedWeight, solution)
+		if got := prioritizedValue(solution.Items); math.Abs(got-best) > 1e-9 {
+			t.Errorf("seed %d: prioritized value %g, want %g", seed, got, best)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import "math"
+
+// An OptimalityProof says how far a solution can be from the optimum.
+// No packing is worth more than UpperBound, and the solution is worth
+// LowerBound. Proven is true when that makes the solution optimal: the
+// bounds meet, or the solver searched exhaustively.
+type OptimalityProof struct {
+	LowerBound, UpperBound int
+	Proven                 bool
+}
+
+// The algorithms that chooseAuto may pick and that always find an optimum.
+var exactAlgorithms = map[string]bool{
+	"Exhaustive search":   true,
+	"Branch and bound":    true,
+	"Dynamic programming": true,
+}
+
+// Solve the items with solveAuto, which logs the algorithm it picks.
+// Return the solution and a proof of how close it is to optimal,
+// so callers can trust the result without rerunning it.
+func Solve(items []Item, allowedWeight int) (Solution, OptimalityProof) {
+	solution := solveAuto(items, allowedWeight)
+	return solution, proveOptimal(items, allowedWeight, solution, exactAlgorithms[solution.Algorithm])
+}
+
+// Return the proof for a solution to the items.
+// exact says the solver searched exhaustively, which proves
+// the solution optimal even if the bounds don't meet.
+func proveOptimal(items []Item, allowedWeight int, solution Solution, exact bool) OptimalityProof {
+	upperBound := lpUpperBound(items, allowedWeight)
+	return OptimalityProof{solution.Value, upperBound, exact || solution.Value == upperBound}
+}
+
+// Return bounds on the optimum in O(n log n) time, without a search.
+// The lower bound is the value greedy finds, which is feasible, and the
+// upper bound is lpUpperBound. If they're equal, greedy is optimal.
+func quickBounds(items []Item, allowedWeight int) (int, int) {
+	_, lower, _ := withoutNegativeItems(greedy)(copyItems(items), allowedWeight)
+	return lower, lpUpperBound(items, allowedWeight)
+}
+
+// Return the LP relaxation rounded down, which bounds the optimum
+// because every packing is worth a whole number.
+func lpUpperBound(items []Item, allowedWeight int) int {
+	lpValue, _ := lpRelaxation(items, allowedWeight)
+	// Allow for rounding error in the fractional item.
+	return int(math.Floor(lpValue + 1e-9))
+}
+
+// Return the LP relaxation of the items other than the one with the given
+// id, rounded down: a bound on any packing that leaves that item out.
+// It is much tighter than subtracting the item's value from the total.
+//
+// Branch and bound doesn't need it: its exclude branch already bounds
+// itself by the LP relaxation of the items after the one left out.
+// Checking that bound before the call instead can't save any Calls,
+// since a branch pruned before the call counts as a call anyway.
+func fractionalBoundExcluding(items []Item, allowedWeight, excludeID int) int {
+	order := []int{}
+	for _, i := range ratioOrder(items) {
+		if items[i].id != excludeID {
+			order = append(order, i)
+		}
+	}
+	lpValue, _ := relaxedValue(items, order, 0, allowedWeight)
+	// Allow for rounding error in the fractional item.
+	return int(math.Floor(lpValue + 1e-9))
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestQuickBoundsBracketOptimum(t *testing.T) {
+	for seed := int64(1); seed <= 200; seed++ {
+		items, allowedWeight := seedInstance(seed, 20)
+		_, optimum, _ := dynamicProgramming(copyItems(items), allowedWeight)
+
+		lower, upper := quickBounds(items, allowedWeight)
+		if lower > optimum || optimum > upper {
+			t.Errorf("seed %d: bounds [%d, %d] don't contain the optimum %d", seed, lower, upper, optimum)
+		}
+	}
+}
+
+func TestFractionalBoundExcludingBoundsPackingsWithoutTheItem(t *testing.T) {
+	for seed := int64(1); seed <= 50; seed++ {
+		items, allowedWeight := seedInstance(seed, 15)
+		for excluded := range items {
+			rest := slices.Delete(copyItems(items), excluded, excluded+1)
+			for i := range rest {
+				rest[i].id = i
+			}
+			_, optimum, _ := dynamicProgramming(rest, allowedWeight)
+			if bound := fractionalBoundExcluding(items, allowedWeight, excluded); bound < optimum {
+				t.Errorf("seed %d: the bound without item %d is %d, less than the optimum %d without it",
+					seed, excluded, bound, optimum)
+			}
+		}
+	}
+}
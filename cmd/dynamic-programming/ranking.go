@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// Print a CSV line for each item in decreasing value/weight order,
+// showing whether the dynamic programming optimum selected it.
+// Where the selected column stops being a run of trues
+// is where greedy and the optimum part ways.
+func printRanking(w io.Writer, items []Item, allowedWeight int) {
+	best, _, _ := dynamicProgramming(copyItems(items), allowedWeight)
+
+	fmt.Fprintln(w, "rank,id,value,weight,ratio,selected")
+	for rank, i := range ratioOrder(items) {
+		// Weightless items get a ratio of +Inf, or NaN if they're worthless too.
+		ratio := float64(items[i].value) / float64(items[i].weight)
+		fmt.Fprintf(w, "%d,%d,%d,%d,%f,%t\n", rank+1, items[i].id,
+			items[i].value, items[i].weight, ratio, best[i].isSelected)
+	}
+}
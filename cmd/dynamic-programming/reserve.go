@@ -0,0 +1,16 @@
+package main
+
+import "fmt"
+
+// Use dynamic programming to find the most valuable packing that leaves at
+// least spare of the capacity unused, say for souvenirs on the way home.
+// That's the same as packing a knapsack spare smaller, so the solution's
+// AllowedWeight is capacity - spare.
+// Return an error if spare is negative or more than the capacity.
+func knapsackWithReserve(items []Item, capacity, spare int) (Solution, error) {
+	if spare < 0 || spare > capacity {
+		return Solution{}, fmt.Errorf("reserve %d isn't between 0 and the capacity %d", spare, capacity)
+	}
+	name := fmt.Sprintf("Dynamic programming, reserving %d", spare)
+	return solve(name, dynamicProgramming, items, capacity-spare), nil
+}
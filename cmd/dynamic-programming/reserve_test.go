@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestKnapsackWithReserveLeavesSpare(t *testing.T) {
+	items, capacity := seedInstance(4, 25)
+	for _, spare := range []int{0, 1, 5, capacity / 2, capacity} {
+		solution, err := knapsackWithReserve(items, capacity, spare)
+		if err != nil {
+			t.Fatalf("reserving %d of %d: %v", spare, capacity, err)
+		}
+		requireFeasible(t, items, capacity-spare, solution)
+		if weight := sumWeights(solution.Items, false); weight > capacity-spare {
+			t.Errorf("reserving %d of %d: packed weight %d", spare, capacity, weight)
+		}
+		if _, want, _ := dynamicProgramming(copyItems(items), capacity-spare); solution.Value != want {
+			t.Errorf("reserving %d of %d: found %d, want %d", spare, capacity, solution.Value, want)
+		}
+	}
+
+	for _, spare := range []int{-1, capacity + 1} {
+		if _, err := knapsackWithReserve(items, capacity, spare); err == nil {
+			t.Errorf("reserving %d of %d didn't fail", spare, capacity)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+// Use Sahni's approximation scheme: try every subset of at most k items
+// as a base, fill the rest of each greedily in decreasing value/weight
+// order, and keep the best. The result is worth at least k/(k+1) times
+// the best possible value, and bigger subsets only add candidates, so
+// raising k never makes it worse. k=0 is greedy, and k=numItems is
+// exhaustive search.
+// Return the assignment, value of that assignment,
+// and the number of subsets we tried.
+func sahniApprox(items []Item, allowedWeight int, k int) ([]Item, int, int) {
+	order := ratioOrder(items)
+	base := make([]bool, len(items))
+	var bestBase []int
+	bestValue := -1
+	subsets := 0
+
+	// Extend the base with items from and later, up to k of them.
+	var tryBases func(from int, chosen []int, baseValue, baseWeight int)
+	tryBases = func(from int, chosen []int, baseValue, baseWeight int) {
+		subsets++
+		value := baseValue + greedyFill(items, order, base, allowedWeight-baseWeight, nil)
+		if value > bestValue {
+			bestValue = value
+			bestBase = append(bestBase[:0], chosen...)
+		}
+		if len(chosen) == k {
+			return
+		}
+		for i := from; i < len(items); i++ {
+			if baseWeight+items[i].weight > allowedWeight {
+				continue
+			}
+			base[i] = true
+			tryBases(i+1, append(chosen, i), baseValue+items[i].value, baseWeight+items[i].weight)
+			base[i] = false
+		}
+	}
+	tryBases(0, []int{}, 0, 0)
+
+	// Rebuild the best solution.
+	baseWeight := 0
+	for _, i := range bestBase {
+		base[i] = true
+		items[i].isSelected = true
+		baseWeight += items[i].weight
+	}
+	greedyFill(items, order, base, allowedWeight-baseWeight, items)
+	return items, bestValue, subsets
+}
+
+// Take the items not in the base in the given order while they fit
+// in the remaining weight. If selection isn't nil, mark the items
+// taken in it.
+// Return the value of the items taken.
+func greedyFill(items []Item, order []int, base []bool, remaining int, selection []Item) int {
+	value := 0
+	for _, i := range order {
+		if !base[i] && items[i].weight <= remaining {
+			value += items[i].value
+			remaining -= items[i].weight
+			if selection != nil {
+				selection[i].isSelected = true
+			}
+		}
+	}
+	return value
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSahniApproxNeverWorsensWithK(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		items, allowedWeight := seedInstance(seed, 12)
+		_, optimum, _ := dynamicProgramming(copyItems(items), allowedWeight)
+
+		previous := 0
+		for k := 0; k <= len(items); k++ {
+			alg := func(items []Item, allowedWeight int) ([]Item, int, int) {
+				return sahniApprox(items, allowedWeight, k)
+			}
+			solution := solve(fmt.Sprintf("Sahni, k=%d", k), alg, items, allowedWeight)
+			requireFeasible(t, items, allowedWeight, solution)
+			if solution.Value < previous || solution.Value > optimum {
+				t.Errorf("seed %d, k %d: found %d, outside %d for k-1 and the optimum %d",
+					seed, k, solution.Value, previous, optimum)
+			}
+			previous = solution.Value
+		}
+		if previous != optimum {
+			t.Errorf("seed %d: with k the number of items, found %d, want the optimum %d", seed, previous, optimum)
+		}
+	}
+}
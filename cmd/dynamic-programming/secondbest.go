@@ -0,0 +1,49 @@
+package main
+
+// Return the best value of any feasible selection that is worth strictly
+// less than the optimum, or -1 if every selection is worth the same.
+// A big gap below the optimum means it's robust. A small one means
+// near-ties, where a small change to the items could change the answer.
+//
+// This is dynamic programming that keeps the two best distinct values
+// for each weight instead of one. The values reachable with items 0
+// through i are those reachable without item i plus those reachable with
+// it, so the two best of each side are enough to find the two best overall.
+// Penalty items are left out, as the solvers leave them out.
+func secondBestValue(items []Item, allowedWeight int) int {
+	allowedWeight = tableWeight(items, allowedWeight)
+
+	// top[w] holds the two best distinct values weighing at most w,
+	// best first, with -1 for none. Only the empty selection fits at first.
+	top := make([][2]int, allowedWeight+1)
+	for w := range top {
+		top[w] = [2]int{0, -1}
+	}
+
+	for _, item := range items {
+		if item.value < 0 {
+			continue
+		}
+		// Go down so each item is used at most once.
+		for w := allowedWeight; w >= item.weight; w-- {
+			with := top[w-item.weight]
+			for _, value := range with {
+				if value >= 0 {
+					top[w] = addTopValue(top[w], value+item.value)
+				}
+			}
+		}
+	}
+	return top[allowedWeight][1]
+}
+
+// Return the two best distinct values of the pair and the new value.
+func addTopValue(pair [2]int, value int) [2]int {
+	switch {
+	case value > pair[0]:
+		return [2]int{value, pair[0]}
+	case value < pair[0] && value > pair[1]:
+		return [2]int{pair[0], value}
+	}
+	return pair
+}
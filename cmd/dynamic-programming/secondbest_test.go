@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestSecondBestValue(t *testing.T) {
+	// The feasible selections are worth 0, 9, 10, 7, 19 (items 0 and 1),
+	// 16 (0 and 2) and 17 (1 and 2). All three weigh 14, too much.
+	// Item 3 is a penalty, which the solvers leave out.
+	items := []Item{newItem(0, 9, 5), newItem(1, 10, 4), newItem(2, 7, 5), newItem(3, -5, 1)}
+	if got := secondBestValue(items, 10); got != 17 {
+		t.Errorf("got %d, want 17", got)
+	}
+
+	// With capacity 4, only the empty selection and item 1 fit.
+	if got := secondBestValue(items, 4); got != 0 {
+		t.Errorf("with capacity 4, got %d, want 0", got)
+	}
+
+	// Every selection of worthless items is worth the same.
+	worthless := []Item{newItem(0, 0, 3), newItem(1, 0, 2)}
+	if got := secondBestValue(worthless, 10); got != -1 {
+		t.Errorf("with worthless items, got %d, want -1", got)
+	}
+}
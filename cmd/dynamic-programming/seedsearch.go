@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// The number of items in each instance -seed-search tries.
+// It's small so dynamic programming finds each optimum quickly
+// and the counterexample is easy to follow by hand.
+const seedSearchItems = 10
+
+// Return the seed's instance of numItems items and its allowed weight.
+func seedInstance(seed int64, numItems int) ([]Item, int) {
+	random := rand.New(rand.NewSource(seed))
+	items := makeItemsFrom(random, numItems, uniformValues(minValue, maxValue), minWeight, maxWeight)
+	return items, int(float64(sumWeights(items, true)) * *capacityFraction)
+}
+
+// Try seeds 1 through numSeeds and return the one whose instance gives
+// greedy the lowest value relative to the optimum, and that ratio.
+// Ties keep the earliest seed.
+func worstGreedySeed(numSeeds int) (int64, float64) {
+	worstSeed, worstRatio := int64(0), 2.0
+	for seed := int64(1); seed <= int64(numSeeds); seed++ {
+		// Neither algorithm reorders the items, so they can share them.
+		items, allowedWeight := seedInstance(seed, seedSearchItems)
+		_, greedyValue, _ := greedy(items, allowedWeight)
+		resetSelection(items)
+		_, bestValue, _ := dynamicProgramming(items, allowedWeight)
+		if bestValue == 0 {
+			continue
+		}
+		ratio := float64(greedyValue) / float64(bestValue)
+		if ratio < worstRatio {
+			worstSeed, worstRatio = seed, ratio
+		}
+	}
+	return worstSeed, worstRatio
+}
+
+// Print the seed where greedy does worst, its ratio,
+// and the instance in the form -items reads.
+func printSeedSearch(numSeeds int) {
+	seed, ratio := worstGreedySeed(numSeeds)
+	if seed == 0 {
+		fmt.Println("No instance with a positive optimum")
+		return
+	}
+
+	items, allowedWeight := seedInstance(seed, seedSearchItems)
+	_, greedyValue, _ := greedy(copyItems(items), allowedWeight)
+	_, bestValue, _ := dynamicProgramming(copyItems(items), allowedWeight)
+
+	fmt.Printf("Worst seed: %d\n", seed)
+	fmt.Printf("Greedy/optimal: %.3f (%d/%d)\n", ratio, greedyValue, bestValue)
+	fmt.Printf("Allowed weight: %d\n", allowedWeight)
+	fmt.Printf("Items: %q\n", formatItems(items))
+}
+
+// Return the items as space-separated value,weight pairs,
+// the form that -items reads.
+func formatItems(items []Item) string {
+	pairs := make([]string, len(items))
+	for i, item := range items {
+		pairs[i] = fmt.Sprintf("%d,%d", item.value, item.weight)
+	}
+	return strings.Join(pairs, " ")
+}
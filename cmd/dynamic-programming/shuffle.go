@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Return the items in an order fixed by the seed, renumbered so ids
+// match positions, and the permutation: position i holds the item
+// that was at perm[i]. Prerequisites are renumbered to match.
+// Branch and bound's calls depend heavily on the
+// order, so shuffling one instance separates order effects from
+// instance effects.
+func shuffleItems(items []Item, seed int64) ([]Item, []int) {
+	perm := rand.New(rand.NewSource(seed)).Perm(len(items))
+	shuffled := make([]Item, len(items))
+	for i, p := range perm {
+		shuffled[i] = items[p]
+	}
+	renumberKept(shuffled, perm, len(items))
+	return shuffled, perm
+}
+
+// Run branch and bound on numRuns shuffles of the items,
+// using seeds firstSeed and up.
+// Return a summary of the calls it made.
+func shuffleCallStats(items []Item, allowedWeight int, firstSeed int64, numRuns int) string {
+	minCalls, maxCalls := math.MaxInt, 0
+	sum, sumSquares := 0.0, 0.0
+	for run := 0; run < numRuns; run++ {
+		shuffled, _ := shuffleItems(items, firstSeed+int64(run))
+		calls := solve("Branch and Bound", branchAndBound, shuffled, allowedWeight).Calls
+		minCalls = min(minCalls, calls)
+		maxCalls = max(maxCalls, calls)
+		sum += float64(calls)
+		sumSquares += float64(calls) * float64(calls)
+	}
+
+	mean := sum / float64(numRuns)
+	stddev := math.Sqrt(max(0, sumSquares/float64(numRuns)-mean*mean))
+	return fmt.Sprintf("Branch and bound calls over %d shuffles (seeds %d-%d): min %d, mean %.0f, stddev %.0f, max %d",
+		numRuns, firstSeed, firstSeed+int64(numRuns)-1, minCalls, mean, stddev, maxCalls)
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+// A Solution is what an algorithm found for a knapsack.
+type Solution struct {
+	Algorithm string // The name of the algorithm that found the solution.
+	Items     []Item // The items, with the ones in the knapsack selected.
+	Value     int    // The total value of the selected items.
+	Calls     int    // The number of function calls the algorithm made.
+
+	AllowedWeight int // The weight the knapsack could hold.
+}
+
+// Return a one-line summary of the solution.
+func (s Solution) String() string {
+	return fmt.Sprintf("%s: Value: %d, Weight: %d, Calls: %d, Utilization: %.1f%%",
+		s.Algorithm, s.Value, sumWeights(s.Items, false), s.Calls,
+		utilization(s.Items, s.AllowedWeight))
+}
+
+// Return the ids of the selected items in increasing order.
+func (s Solution) SelectedIDs() Selection {
+	ids := selectedIDs(s.Items)
+	slices.Sort(ids)
+	return ids
+}
+
+// Return true if the two solutions have the same value and weight and
+// select the same ids, in whatever order their items are in.
+// The algorithm, calls and allowed weight don't matter.
+func (s Solution) Equal(other Solution) bool {
+	return s.Value == other.Value &&
+		sumWeights(s.Items, false) == sumWeights(other.Items, false) &&
+		slices.Equal(s.SelectedIDs(), other.SelectedIDs())
+}
+
+// Run the algorithm on a copy of the items and return its solution.
+func solve(name string, alg func([]Item, int) ([]Item, int, int), items []Item, allowedWeight int) Solution {
+	solution, totalValue, functionCalls := withoutNegativeItems(withoutOverweightItems(alg))(deepCopyItems(items), allowedWeight)
+	return Solution{name, solution, totalValue, functionCalls, allowedWeight}
+}
+
+// Check that a solution to the items is feasible and honest: it has one
+// entry per item, its selected items fit within the capacity, and its
+// Value is what they're really worth. This catches a solver that "wins"
+// with an overweight selection.
+func checkFeasible(items []Item, capacity int, s Solution) error {
+	if len(s.Items) != len(items) {
+		return fmt.Errorf("%s: %d items in the solution, want %d", s.Algorithm, len(s.Items), len(items))
+	}
+	if weight := sumWeights(s.Items, false); weight > capacity {
+		return fmt.Errorf("%s: selected weight %d exceeds capacity %d", s.Algorithm, weight, capacity)
+	}
+	if value := sumValues(s.Items, false); value != s.Value {
+		return fmt.Errorf("%s: reported value %d, but the selected items are worth %d", s.Algorithm, s.Value, value)
+	}
+	return nil
+}
+
+// A Selection holds the ids of the selected items.
+type Selection []int
+
+// Return the ids of the selected items.
+func selectedIDs(items []Item) Selection {
+	selection := Selection{}
+	for _, item := range items {
+		if item.isSelected {
+			selection = append(selection, item.id)
+		}
+	}
+	return selection
+}
+
+// A candidate is a solution that a search is considering.
+// A nil items slice means the search pruned the branch.
+type candidate struct {
+	items []Item
+	value int
+	calls int
+}
+
+// Return the better of two candidates for the same items in the same order:
+// the one with the higher value or, if the values tie, the one whose
+// selected ids come first in sorted order. A pruned candidate loses to
+// any other. Every search compares with this so ties break the same way.
+func betterSolution(a, b candidate) candidate {
+	return betterSolutionBy(a, b, preferCanonical)
+}
+
+// How to choose between solutions with the same value.
+type tiePreference int
+
+const (
+	preferCanonical tiePreference = iota // The selected ids that come first in sorted order.
+	preferFewer                          // The fewest selected items.
+	preferMore                           // The most selected items.
+	preferLighter                        // The lowest total weight.
+)
+
+var tiePreferenceNames = map[string]tiePreference{
+	"canonical": preferCanonical,
+	"fewer":     preferFewer,
+	"more":      preferMore,
+	"lighter":   preferLighter,
+}
+
+// Like betterSolution, but break value ties by the preference.
+// Candidates that the preference can't tell apart fall back to the
+// canonical order, so the result never depends on which came first.
+func betterSolutionBy(a, b candidate, prefer tiePreference) candidate {
+	if (a.items == nil) != (b.items == nil) {
+		if a.items == nil {
+			return b
+		}
+		return a
+	}
+	if a.value != b.value {
+		if a.value > b.value {
+			return a
+		}
+		return b
+	}
+
+	// Return the preference's measure of a candidate, where lower is better.
+	measure := func(c candidate) int {
+		switch prefer {
+		case preferFewer:
+			return len(selectedIDs(c.items))
+		case preferMore:
+			return -len(selectedIDs(c.items))
+		case preferLighter:
+			return sumWeights(c.items, false)
+		}
+		return 0
+	}
+	if prefer != preferCanonical && a.items != nil {
+		if ma, mb := measure(a), measure(b); ma != mb {
+			if ma < mb {
+				return a
+			}
+			return b
+		}
+	}
+
+	if selectsEarlier(b.items, a.items) {
+		return b
+	}
+	return a
+}
+
+// Return true if a's selected ids come before b's in sorted order,
+// comparing id by id, with a prefix coming first.
+func selectsEarlier(a, b []Item) bool {
+	for i := range a {
+		if a[i].isSelected == b[i].isSelected {
+			continue
+		}
+		// The one that selects item i comes first,
+		// unless the other has run out of selected items.
+		if a[i].isSelected {
+			return anySelected(b[i+1:])
+		}
+		return !anySelected(a[i+1:])
+	}
+	return false
+}
+
+// Return true if any of the items is selected.
+func anySelected(items []Item) bool {
+	for _, item := range items {
+		if item.isSelected {
+			return true
+		}
+	}
+	return false
+}
+
+// Return the items that aren't selected.
+func unselected(items []Item) []Item {
+	leftovers := []Item{}
+	for _, item := range items {
+		if !item.isSelected {
+			leftovers = append(leftovers, item)
+		}
+	}
+	return leftovers
+}
+
+// Compare two solutions to the same items.
+// Return the ids of the items that only a selected, the ids of the items
+// that only b selected, and whether the two have the same total value.
+func diffSolutions(a, b []Item) ([]int, []int, bool) {
+	inA := make(map[int]bool)
+	for _, id := range selectedIDs(a) {
+		inA[id] = true
+	}
+	inB := make(map[int]bool)
+	for _, id := range selectedIDs(b) {
+		inB[id] = true
+	}
+
+	onlyA := []int{}
+	for _, id := range selectedIDs(a) {
+		if !inB[id] {
+			onlyA = append(onlyA, id)
+		}
+	}
+	onlyB := []int{}
+	for _, id := range selectedIDs(b) {
+		if !inA[id] {
+			onlyB = append(onlyB, id)
+		}
+	}
+	return onlyA, onlyB, sumValues(a, false) == sumValues(b, false)
+}
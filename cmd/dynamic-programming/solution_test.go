@@ -0,0 +1,136 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// Fail the test unless the solution to the items fits within the capacity
+// and its Value is what its selected items are worth. Every solver test
+// calls this, so no solver can win with an overweight selection.
+func requireFeasible(t *testing.T, items []Item, capacity int, s Solution) {
+	t.Helper()
+	if err := checkFeasible(items, capacity, s); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSolutionEqualIgnoresOrder(t *testing.T) {
+	a := []Item{newItem(0, 9, 5), newItem(1, 10, 4), newItem(2, 7, 5)}
+	a[0].isSelected = true
+	a[2].isSelected = true
+	b := []Item{a[2], a[1], a[0]}
+
+	first := Solution{"first", a, 16, 3, 10}
+	second := Solution{"second", b, 16, 7, 12}
+	if !first.Equal(second) {
+		t.Errorf("solutions selecting %v and %v aren't Equal", first.SelectedIDs(), second.SelectedIDs())
+	}
+
+	b[1].isSelected = true
+	third := Solution{"third", b, 26, 7, 14}
+	if first.Equal(third) {
+		t.Errorf("solutions selecting %v and %v are Equal", first.SelectedIDs(), third.SelectedIDs())
+	}
+}
+
+func TestExhaustiveSearchBreaksTiesByPreference(t *testing.T) {
+	// Item 0 alone and items 1 and 2 together are both worth 6, the most
+	// that fits, but the pair has more items and weighs less.
+	items := []Item{newItem(0, 6, 6), newItem(1, 3, 2), newItem(2, 3, 3)}
+	for _, test := range []struct {
+		prefer tiePreference
+		want   Selection
+	}{
+		{preferCanonical, Selection{0}},
+		{preferFewer, Selection{0}},
+		{preferMore, Selection{1, 2}},
+		{preferLighter, Selection{1, 2}},
+	} {
+		for _, alg := range []Algorithm{
+			{"Exhaustive search", exhaustiveSearchPreferring(test.prefer)},
+			{"Parallel exhaustive search", parallelExhaustiveSearchPreferring(test.prefer)},
+			{"Exhaustive search, Gray code", exhaustiveSearchGrayPreferring(test.prefer)},
+		} {
+			solution := solve(alg.Name, alg.Run, items, 6)
+			requireFeasible(t, items, 6, solution)
+			if got := solution.SelectedIDs(); !slices.Equal(got, test.want) {
+				t.Errorf("%s preferring %d selected %v, want %v", alg.Name, test.prefer, got, test.want)
+			}
+		}
+	}
+}
+
+func TestDiffSolutions(t *testing.T) {
+	// a selects 0, 1 and 2, b selects 1, 2 and 3. Item 4 is in neither.
+	a := []Item{newItem(0, 4, 1), newItem(1, 5, 2), newItem(2, 6, 3), newItem(3, 7, 4), newItem(4, 8, 5)}
+	b := copyItems(a)
+	for _, id := range []int{0, 1, 2} {
+		a[id].isSelected = true
+	}
+	for _, id := range []int{1, 2, 3} {
+		b[id].isSelected = true
+	}
+
+	onlyA, onlyB, sameValue := diffSolutions(a, b)
+	if !slices.Equal(onlyA, []int{0}) || !slices.Equal(onlyB, []int{3}) || sameValue {
+		t.Errorf("got only a %v, only b %v, same value %t, want [0], [3], false", onlyA, onlyB, sameValue)
+	}
+
+	// b now selects 3 and 4, none of a's items but worth the same.
+	resetSelection(b)
+	b[3].isSelected = true
+	b[4].isSelected = true
+	onlyA, onlyB, sameValue = diffSolutions(a, b)
+	if !slices.Equal(onlyA, []int{0, 1, 2}) || !slices.Equal(onlyB, []int{3, 4}) || !sameValue {
+		t.Errorf("got only a %v, only b %v, same value %t, want [0 1 2], [3 4], true", onlyA, onlyB, sameValue)
+	}
+
+	onlyA, onlyB, sameValue = diffSolutions(a, a)
+	if len(onlyA) != 0 || len(onlyB) != 0 || !sameValue {
+		t.Errorf("a solution differs from itself by only a %v, only b %v, same value %t", onlyA, onlyB, sameValue)
+	}
+}
+
+func TestBetterSolutionPinsTieBreak(t *testing.T) {
+	// Return a candidate for four items with the ids selected.
+	selecting := func(value int, ids ...int) candidate {
+		items := []Item{newItem(0, 1, 1), newItem(1, 1, 1), newItem(2, 1, 1), newItem(3, 1, 1)}
+		for _, id := range ids {
+			items[id].isSelected = true
+		}
+		return candidate{items, value, 0}
+	}
+
+	for _, test := range []struct {
+		a, b candidate
+		want Selection
+	}{
+		{selecting(5, 1, 2), selecting(6, 0), Selection{0}},             // The higher value wins.
+		{selecting(6, 0, 3), selecting(6, 1, 2), Selection{0, 3}},       // The earlier first id wins a tie.
+		{selecting(6, 0, 2), selecting(6, 0, 1, 3), Selection{0, 1, 3}}, // Then the earlier second id.
+		{selecting(6, 0), selecting(6, 0, 1), Selection{0}},             // A prefix comes first.
+		{candidate{nil, 0, 0}, selecting(0), Selection{}},               // A pruned candidate loses.
+		{selecting(6, 2), selecting(6, 2), Selection{2}},                // Equal ones are equal.
+	} {
+		for _, order := range [][2]candidate{{test.a, test.b}, {test.b, test.a}} {
+			got := selectedIDs(betterSolution(order[0], order[1]).items)
+			if !slices.Equal(got, test.want) {
+				t.Errorf("betterSolution(%v, %v) selected %v, want %v",
+					selectedIDs(order[0].items), selectedIDs(order[1].items), got, test.want)
+			}
+		}
+	}
+
+	// Every pair of these items is worth the same, so only the tie-break
+	// decides which pair the searches select.
+	items := []Item{newItem(0, 5, 5), newItem(1, 5, 5), newItem(2, 5, 5), newItem(3, 5, 5)}
+	for _, alg := range []Algorithm{
+		{"Exhaustive search", exhaustiveSearch},
+		{"Branch and bound", branchAndBound},
+	} {
+		if got := solve(alg.Name, alg.Run, items, 10).SelectedIDs(); !slices.Equal(got, Selection{0, 1}) {
+			t.Errorf("%s selected %v, want [0 1]", alg.Name, got)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import "context"
+
+// Run branch and bound in the background and send each solution that
+// beats all earlier ones on the returned channel as soon as it's found,
+// so a caller can show progress on a long search. When the search
+// finishes, the last solution sent is the one branchAndBound would return.
+// The channel is closed when the search finishes or ctx is done.
+func solveStream(ctx context.Context, items []Item, allowedWeight int) <-chan Solution {
+	solutions := make(chan Solution)
+
+	go func() {
+		defer close(solutions)
+
+		// Send a solution unless ctx is done first.
+		var last Solution
+		send := func(solution Solution) bool {
+			select {
+			case solutions <- solution:
+				last = solution
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// The bounds assume values aren't negative, so leave out the penalties
+		// and map each solution back onto the original items.
+		_, _, positions := filterItems(items, nonNegativeValue)
+		var search *bnbSearch
+		alg := func(kept []Item, allowedWeight int) ([]Item, int, int) {
+			search = &bnbSearch{minWeights: suffixMinWeights(kept), order: ratioOrder(kept), ctx: ctx}
+			search.improved = func(found []Item, value int) {
+				send(Solution{"Branch and bound", restoreSelection(items, found, positions), value, search.calls, allowedWeight})
+			}
+			return searchBranchAndBound(kept, search, allowedWeight)
+		}
+		solution, totalValue, functionCalls := withoutNegativeItems(alg)(deepCopyItems(items), allowedWeight)
+		if ctx.Err() != nil || (search != nil && search.cancelled) {
+			return
+		}
+
+		// Equally good solutions found later can win the tie-break,
+		// so make sure the final answer is the last one sent.
+		final := Solution{"Branch and bound", solution, totalValue, functionCalls, allowedWeight}
+		if last.Items == nil || !last.Equal(final) {
+			send(final)
+		}
+	}()
+	return solutions
+}
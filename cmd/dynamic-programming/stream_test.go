@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSolveStreamEndsWithTheBatchSolution(t *testing.T) {
+	for seed := int64(1); seed <= 10; seed++ {
+		items, allowedWeight := seedInstance(seed, 30)
+		// A penalty item checks that the solutions map back onto all of the items.
+		items[4].value = -3
+
+		var last Solution
+		previous := -1
+		for solution := range solveStream(context.Background(), items, allowedWeight) {
+			requireFeasible(t, items, allowedWeight, solution)
+			if solution.Value < previous {
+				t.Errorf("seed %d: a solution worth %d came after one worth %d", seed, solution.Value, previous)
+			}
+			previous = solution.Value
+			last = solution
+		}
+
+		batch := solve("Branch and bound", branchAndBound, items, allowedWeight)
+		if !last.Equal(batch) {
+			t.Errorf("seed %d: the stream ended with %v worth %d, branch and bound found %v worth %d",
+				seed, last.SelectedIDs(), last.Value, batch.SelectedIDs(), batch.Value)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import "context"
+
+// Use dynamic programming on items that arrive on a channel, adding one
+// row to the table as each one comes in, so a producer can generate the
+// items lazily instead of building the whole list first. The DPSolver
+// still keeps the items it has seen, to mark the selection at the end.
+// Each item's id is set to its position in the stream.
+// Return the solution once the channel is closed. If ctx is done first,
+// return an empty Solution and ctx.Err().
+func solveStreaming(ctx context.Context, itemsCh <-chan Item, capacity int) (Solution, error) {
+	solver := NewDPSolver(capacity)
+	for {
+		select {
+		case item, ok := <-itemsCh:
+			if !ok {
+				items := solver.Items()
+				return Solution{"Dynamic programming, streamed", items, solver.Best(), len(items), capacity}, nil
+			}
+			solver.AddItem(item)
+		case <-ctx.Done():
+			return Solution{}, ctx.Err()
+		}
+	}
+}
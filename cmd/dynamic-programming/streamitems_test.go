@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSolveStreamingMatchesBatch(t *testing.T) {
+	for seed := int64(1); seed <= 10; seed++ {
+		items, allowedWeight := seedInstance(seed, 30)
+		itemsCh := make(chan Item)
+		go func() {
+			defer close(itemsCh)
+			for _, item := range items {
+				itemsCh <- item
+			}
+		}()
+
+		streamed, err := solveStreaming(context.Background(), itemsCh, allowedWeight)
+		if err != nil {
+			t.Fatal(err)
+		}
+		requireFeasible(t, items, allowedWeight, streamed)
+		batch := solve("Dynamic programming", dynamicProgramming, items, allowedWeight)
+		if streamed.Value != batch.Value {
+			t.Errorf("seed %d: streamed %v worth %d, batch %v worth %d",
+				seed, streamed.SelectedIDs(), streamed.Value, batch.SelectedIDs(), batch.Value)
+		}
+	}
+}
+
+func TestSolveStreamingStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// Nothing is ever sent, so only the cancellation can end the solve.
+	_, err := solveStreaming(ctx, make(chan Item), 10)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import "context"
+
+// Use branch and bound to find the best solution before ctx is done.
+// If ctx is done first, return an empty Solution and ctx.Err() rather than
+// the best solution found so far, which might not be optimal.
+func branchAndBoundContext(ctx context.Context, items []Item, allowedWeight int) (Solution, error) {
+	if err := ctx.Err(); err != nil {
+		return Solution{}, err
+	}
+
+	// The bounds assume values aren't negative, so leave out the penalties.
+	var search *bnbSearch
+	alg := func(items []Item, allowedWeight int) ([]Item, int, int) {
+		search = &bnbSearch{minWeights: suffixMinWeights(items), order: ratioOrder(items), ctx: ctx}
+		return searchBranchAndBound(items, search, allowedWeight)
+	}
+	solution, totalValue, functionCalls := withoutNegativeItems(alg)(deepCopyItems(items), allowedWeight)
+	if search != nil && search.cancelled {
+		return Solution{}, ctx.Err()
+	}
+	return Solution{"Branch and bound", solution, totalValue, functionCalls, allowedWeight}, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBranchAndBoundContextCompletes(t *testing.T) {
+	items, allowedWeight := seedInstance(7, 30)
+	solution, err := branchAndBoundContext(context.Background(), items, allowedWeight)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireFeasible(t, items, allowedWeight, solution)
+	want := solve("Branch and bound", branchAndBound, items, allowedWeight)
+	if !solution.Equal(want) {
+		t.Errorf("selected %v worth %d, branch and bound %v worth %d",
+			solution.SelectedIDs(), solution.Value, want.SelectedIDs(), want.Value)
+	}
+}
+
+// Cut off mid-search, the search returns the deadline error and no
+// solution, not the best one it had found so far.
+func TestBranchAndBoundContextFailsAtDeadline(t *testing.T) {
+	// Branch and bound takes far longer than the deadline on this.
+	items, allowedWeight := makeChvatalInstance(maxChvatalItems)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	solution, err := branchAndBoundContext(ctx, items, allowedWeight)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want the deadline to be exceeded", err)
+	}
+	if solution.Items != nil || solution.Value != 0 {
+		t.Errorf("got a partial solution selecting %v worth %d, want none", solution.SelectedIDs(), solution.Value)
+	}
+}
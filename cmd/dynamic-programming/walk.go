@@ -0,0 +1,32 @@
+package main
+
+// Call visit for every feasible selection of the items, with the ids of
+// the selected items and their total value and weight. Stop early if
+// visit returns false. The selection is reused between calls, so visit
+// must copy it to keep it.
+//
+// This enumerates up to 2^n selections, so it is only practical for
+// about 30 items. Overweight selections are skipped without being
+// extended, which helps when the capacity is small.
+func WalkSolutions(items []Item, allowedWeight int, visit func(sel Selection, value, weight int) bool) {
+	doWalkSolutions(items, allowedWeight, visit, 0, Selection{}, 0, 0)
+}
+
+// Visit the feasible selections that extend the current one
+// by deciding items nextIndex and later.
+// Return false if visit asked to stop.
+func doWalkSolutions(items []Item, allowedWeight int, visit func(Selection, int, int) bool,
+	nextIndex int, current Selection, currentValue, currentWeight int) bool {
+	if currentWeight > allowedWeight {
+		return true
+	}
+	if nextIndex >= len(items) {
+		return visit(current, currentValue, currentWeight)
+	}
+
+	item := items[nextIndex]
+	return doWalkSolutions(items, allowedWeight, visit, nextIndex+1,
+		append(current, item.id), currentValue+item.value, currentWeight+item.weight) &&
+		doWalkSolutions(items, allowedWeight, visit, nextIndex+1,
+			current, currentValue, currentWeight)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestWalkSolutionsVisitsEachFeasibleSelection(t *testing.T) {
+	for seed := int64(1); seed <= 10; seed++ {
+		items, allowedWeight := seedInstance(seed, 12)
+
+		// Count the feasible subsets by brute force.
+		want := 0
+		for mask := 0; mask < 1<<len(items); mask++ {
+			weight := 0
+			for i, item := range items {
+				if mask&(1<<i) != 0 {
+					weight += item.weight
+				}
+			}
+			if weight <= allowedWeight {
+				want++
+			}
+		}
+
+		seen := make(map[string]bool)
+		WalkSolutions(items, allowedWeight, func(sel Selection, value, weight int) bool {
+			key := fmt.Sprint(sel)
+			if seen[key] {
+				t.Errorf("seed %d: visited %v twice", seed, sel)
+			}
+			seen[key] = true
+
+			gotValue, gotWeight := 0, 0
+			for _, id := range sel {
+				gotValue += items[id].value
+				gotWeight += items[id].weight
+			}
+			if gotValue != value || gotWeight != weight || weight > allowedWeight {
+				t.Errorf("seed %d: visited %v with value %d and weight %d, want %d and %d within %d",
+					seed, sel, value, weight, gotValue, gotWeight, allowedWeight)
+			}
+			return true
+		})
+		if len(seen) != want {
+			t.Errorf("seed %d: visited %d selections, want the %d feasible subsets", seed, len(seen), want)
+		}
+	}
+}
+
+func TestWalkSolutionsStopsEarly(t *testing.T) {
+	items, allowedWeight := seedInstance(1, 12)
+	visits := 0
+	WalkSolutions(items, allowedWeight, func(Selection, int, int) bool {
+		visits++
+		return visits < 5
+	})
+	if visits != 5 {
+		t.Errorf("visited %d selections after asking to stop at 5", visits)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// The form -warm-start and external solvers give a solution in: the ids of
+// the selected items, as in one of the results that -format json writes.
+// Other fields are ignored, so such a result can be used as it is.
+type jsonSelection struct {
+	Selected Selection `json:"selected"`
+}
+
+// Read a solution in the jsonSelection form.
+func loadSelection(r io.Reader) (Selection, error) {
+	var selection jsonSelection
+	if err := json.NewDecoder(r).Decode(&selection); err != nil {
+		return nil, fmt.Errorf("reading selection: %w", err)
+	}
+	return selection.Selected, nil
+}
+
+// Check that the selection names each item at most once, names no item
+// that doesn't exist, and fits in the knapsack.
+// Return the selection's value and weight.
+func checkSelection(items []Item, allowedWeight int, start Selection) (int, int, error) {
+	value, weight, err := toInstance(items, allowedWeight).Check(start)
+	if err != nil {
+		return 0, 0, err
+	}
+	return value, weight, nil
+}
+
+// Return a branch and bound that starts with the selection, which
+// checkSelection has accepted, as its best solution so far. The search
+// only has to beat its value, so it prunes from the first node on.
+// If nothing beats it, return the selection, without any negative items.
+// Even a start short of the optimum, like greedy's, prunes many branches
+// the search would otherwise explore before finding one as good.
+//
+// The returned function removes negative items itself, since that
+// renumbers the items and the selection is by the original ids,
+// so run it with runWithPenalties rather than runAlgorithm.
+func branchAndBoundFrom(start Selection) func([]Item, int) ([]Item, int, int) {
+	return func(items []Item, allowedWeight int) ([]Item, int, int) {
+		selected := make(map[int]bool)
+		for _, id := range start {
+			selected[id] = true
+		}
+		warm := copyItems(items)
+		for i := range warm {
+			warm[i].isSelected = selected[warm[i].id] && warm[i].value >= 0
+		}
+		warmValue := sumValues(warm, false)
+
+		solution, totalValue, functionCalls := withoutNegativeItems(func(items []Item, allowedWeight int) ([]Item, int, int) {
+			search := &bnbSearch{minWeights: suffixMinWeights(items), order: ratioOrder(items), incumbent: warmValue}
+			return searchBranchAndBound(items, search, allowedWeight)
+		})(items, allowedWeight)
+		if totalValue < warmValue {
+			return warm, warmValue, functionCalls
+		}
+		return solution, totalValue, functionCalls
+	}
+}
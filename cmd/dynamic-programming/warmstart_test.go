@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+// Starting from greedy's solution, which is short of the optimum on
+// this instance, branch and bound must still find the optimum, and
+// prune enough from the start to make fewer calls than without it.
+func TestWarmStartMakesFewerCalls(t *testing.T) {
+	items, allowedWeight := seedInstance(9, 40)
+	start := solve("Greedy", greedy, items, allowedWeight)
+	cold := solve("Branch and bound", branchAndBound, items, allowedWeight)
+	if start.Value >= cold.Value {
+		t.Fatalf("greedy found %d, the optimum %d, want it short to test the warm start", start.Value, cold.Value)
+	}
+
+	solution, value, calls := branchAndBoundFrom(start.SelectedIDs())(deepCopyItems(items), allowedWeight)
+	warm := Solution{"Warm branch and bound", solution, value, calls, allowedWeight}
+	requireFeasible(t, items, allowedWeight, warm)
+	if warm.Value != cold.Value {
+		t.Errorf("the warm start found %d, want the optimum %d", warm.Value, cold.Value)
+	}
+	if warm.Calls >= cold.Calls {
+		t.Errorf("the warm start made %d calls, no fewer than the %d without it", warm.Calls, cold.Calls)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import "math"
+
+// Use dynamic programming to find the most valuable selection whose total
+// weight is at least minTotalWeight and at most maxTotalWeight, for example
+// when a shipment must weigh enough to qualify for a rate.
+//
+// The table tracks the best value for each exact total weight,
+// so it needs numItems * (maxTotalWeight + 1) cells.
+// Return the selected ids, their total value,
+// and false if no selection weighs enough.
+func knapsackWeightRange(items []Item, minTotalWeight, maxTotalWeight int) (Selection, int, bool) {
+	numItems := len(items)
+	minTotalWeight = max(minTotalWeight, 0)
+	if maxTotalWeight < minTotalWeight {
+		return nil, 0, false
+	}
+
+	// bestValue[w] is the best value of the items so far weighing exactly w.
+	const unreachable = math.MinInt
+	bestValue := make([]int, maxTotalWeight+1)
+	for w := 1; w <= maxTotalWeight; w++ {
+		bestValue[w] = unreachable
+	}
+
+	// took[i][w] is true if item i improved bestValue[w].
+	took := make([][]bool, numItems)
+	for i, item := range items {
+		took[i] = make([]bool, maxTotalWeight+1)
+
+		// Work downwards so item i is only used once.
+		for w := maxTotalWeight; w >= item.weight; w-- {
+			prev := bestValue[w-item.weight]
+			if prev != unreachable && prev+item.value > bestValue[w] {
+				bestValue[w] = prev + item.value
+				took[i][w] = true
+			}
+		}
+	}
+
+	// Find the best reachable weight in the range.
+	bestWeight := -1
+	for w := minTotalWeight; w <= maxTotalWeight; w++ {
+		if bestValue[w] != unreachable && (bestWeight < 0 || bestValue[w] > bestValue[bestWeight]) {
+			bestWeight = w
+		}
+	}
+	if bestWeight < 0 {
+		return nil, 0, false
+	}
+
+	// Work backwards to find the items.
+	selection := Selection{}
+	w := bestWeight
+	for i := numItems - 1; i >= 0; i-- {
+		if took[i][w] {
+			selection = append(Selection{items[i].id}, selection...)
+			w -= items[i].weight
+		}
+	}
+	return selection, bestValue[bestWeight], true
+}
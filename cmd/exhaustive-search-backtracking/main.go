@@ -1,8 +1,10 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math/rand"
+	"os"
 	"time"
 )
 
@@ -15,12 +17,18 @@ const maxWeight = 10
 
 var allowedWeight int
 
+var capacityFraction = flag.Float64("capacity-fraction", 0.5,
+	"allowed weight as a fraction of the items' total weight")
+
 type Item struct {
 	value, weight int
 	isSelected    bool
 }
 
 // TEST RESULTs:
+// makeItems uses the fixed seed 1337, so everything but the elapsed
+// time is reproducible.
+//
 // *** Parameters ***
 // # items: 20
 // Total value: 112
@@ -31,11 +39,18 @@ type Item struct {
 // Elapsed: 0.108564
 // 0(9, 5) 1(10, 4) 2(7, 5) 4(4, 5) 7(9, 6) 9(10, 7) 10(3, 5) 15(6, 5) 17(8, 8) 18(9, 7) 19(7, 5)
 // Value: 82, Weight: 62, Calls: 2097151
+// Utilization: 100.0%
 
 func main() {
+	flag.Parse()
+
 	//items := makeTestItems()
 	items := makeItems(numItems, minValue, maxValue, minWeight, maxWeight)
-	allowedWeight = sumWeights(items, true) / 2
+	if *capacityFraction < 0 {
+		fmt.Fprintln(os.Stderr, "-capacity-fraction must not be negative")
+		os.Exit(2)
+	}
+	allowedWeight = int(float64(sumWeights(items, true)) * *capacityFraction)
 
 	// Display basic parameters.
 	fmt.Println("*** Parameters ***")
@@ -146,9 +161,18 @@ func runAlgorithm(alg func([]Item, int) ([]Item, int, int), items []Item, allowe
 	printSelected(solution)
 	fmt.Printf("Value: %d, Weight: %d, Calls: %d\n",
 		totalValue, sumWeights(solution, false), functionCalls)
+	fmt.Printf("Utilization: %.1f%%\n", utilization(solution, allowedWeight))
 	fmt.Println()
 }
 
+// Return the selected items' weight as a percentage of the allowed weight.
+func utilization(items []Item, allowedWeight int) float64 {
+	if allowedWeight <= 0 {
+		return 0
+	}
+	return float64(sumWeights(items, false)) * 100 / float64(allowedWeight)
+}
+
 // Recursively assign values in or out of the solution.
 // Return the best assignment, value of that assignment,
 // and the number of function calls we made.
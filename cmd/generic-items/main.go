@@ -0,0 +1,367 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const numItems = 25
+
+const minValue = 1
+const maxValue = 10
+const minWeight = 4
+const maxWeight = 10
+
+var capacityFraction = flag.Float64("capacity-fraction", 0.5,
+	"allowed weight as a fraction of the items' total weight")
+var capacity = flag.Float64("capacity", -1,
+	"allowed weight, which may be fractional like 62.5, overriding -capacity-fraction")
+var precision = flag.Int("precision", -1,
+	"digits after the decimal point in values and weights (-1 keeps the default formats)")
+var valueUnit = flag.String("value-unit", "",
+	`unit label for values, like "$"`)
+var weightUnit = flag.String("weight-unit", "",
+	`unit label for weights, like "kg"`)
+
+// Numeric is the set of types that can be used for item values and weights.
+// Unsigned types are left out because solutionValue returns -1 for
+// solutions that are too heavy.
+//
+// Only greedy, fractional, and branch and bound are generic. The dynamic
+// programming solver indexes its table by weight, so it stays integer-only.
+// Greedy and fractional take a float64 allowed weight whatever the item
+// type, so integer items can go in a knapsack that holds 62.5.
+type Numeric interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~float32 | ~float64
+}
+
+type Item[T Numeric] struct {
+	value, weight T
+	isSelected    bool
+}
+
+func main() {
+	flag.Parse()
+	if *capacityFraction < 0 {
+		fmt.Fprintln(os.Stderr, "-capacity-fraction must not be negative")
+		os.Exit(2)
+	}
+
+	// Integer instance.
+	intItems := makeItems(numItems, minValue, maxValue, minWeight, maxWeight)
+	intAllowedWeight := float64(int(float64(sumWeights(intItems, true)) * *capacityFraction))
+	if *capacity >= 0 {
+		intAllowedWeight = *capacity
+	}
+	runAll(intItems, intAllowedWeight)
+
+	// Float instance, e.g. kilograms and dollars.
+	floatItems := makeFloatItems(numItems, minValue, maxValue, minWeight, maxWeight)
+	floatAllowedWeight := sumWeights(floatItems, true) * *capacityFraction
+	if *capacity >= 0 {
+		floatAllowedWeight = *capacity
+	}
+	runAll(floatItems, floatAllowedWeight)
+}
+
+// Display the parameters and run every generic algorithm on the items.
+// Branch and bound gets the allowed weight converted to T, which rounds
+// it down for integer items. They can't use the fraction anyway.
+func runAll[T Numeric](items []Item[T], allowedWeight float64) {
+	// Display basic parameters.
+	fmt.Println("*** Parameters ***")
+	fmt.Printf("# items: %d\n", len(items))
+	fmt.Printf("Total value: %s\n", formatValue(float64(sumValues(items, true)), "%.6g"))
+	fmt.Printf("Total weight: %s\n", formatWeight(float64(sumWeights(items, true)), "%.6g"))
+	fmt.Printf("Allowed weight: %s\n", formatWeight(allowedWeight, "%.6g"))
+	fmt.Println()
+
+	fmt.Println("*** Greedy ***")
+	runAlgorithm(func(items []Item[T], _ T) ([]Item[T], T, int) {
+		return greedy(items, allowedWeight)
+	}, items, T(allowedWeight))
+
+	fmt.Println("*** Fractional ***")
+	value, fractionalIndex, fraction := fractionalKnapsack(copyItems(items), allowedWeight)
+	if fractionalIndex < 0 {
+		fmt.Printf("Value: %s, no fractional item\n", formatValue(value, "%f"))
+	} else {
+		fmt.Printf("Value: %s, %.3f of item %d\n", formatValue(value, "%f"), fraction, fractionalIndex)
+	}
+	fmt.Println()
+
+	// Branch and bound
+	if len(items) > 45 { // Only run branch and bound if numItems <= 45.
+		fmt.Println("Too many items for branch and bound")
+		fmt.Println()
+	} else {
+		fmt.Println("*** Branch and Bound ***")
+		runAlgorithm(branchAndBound[T], items, T(allowedWeight))
+	}
+}
+
+// Make some random items.
+func makeItems(numItems, minValue, maxValue, minWeight, maxWeight int) []Item[int] {
+	// Initialize a pseudorandom number generator.
+	random := rand.New(rand.NewSource(1337)) // Initialize with a fixed seed
+
+	items := make([]Item[int], numItems)
+	for i := 0; i < numItems; i++ {
+		items[i] = Item[int]{
+			random.Intn(maxValue-minValue+1) + minValue,
+			random.Intn(maxWeight-minWeight+1) + minWeight,
+			false}
+	}
+	return items
+}
+
+// Make some random items with fractional values and weights.
+func makeFloatItems(numItems int, minValue, maxValue, minWeight, maxWeight float64) []Item[float64] {
+	// Initialize a pseudorandom number generator.
+	random := rand.New(rand.NewSource(1337)) // Initialize with a fixed seed
+
+	items := make([]Item[float64], numItems)
+	for i := 0; i < numItems; i++ {
+		items[i] = Item[float64]{
+			random.Float64()*(maxValue-minValue) + minValue,
+			random.Float64()*(maxWeight-minWeight) + minWeight,
+			false}
+	}
+	return items
+}
+
+// Return a copy of the items slice.
+func copyItems[T Numeric](items []Item[T]) []Item[T] {
+	newItems := make([]Item[T], len(items))
+	copy(newItems, items)
+	return newItems
+}
+
+// Return the total value of the items.
+// If addAll is false, only add up the selected items.
+func sumValues[T Numeric](items []Item[T], addAll bool) T {
+	var total T
+	for i := 0; i < len(items); i++ {
+		if addAll || items[i].isSelected {
+			total += items[i].value
+		}
+	}
+	return total
+}
+
+// Return the total weight of the items.
+// If addAll is false, only add up the selected items.
+func sumWeights[T Numeric](items []Item[T], addAll bool) T {
+	var total T
+	for i := 0; i < len(items); i++ {
+		if addAll || items[i].isSelected {
+			total += items[i].weight
+		}
+	}
+	return total
+}
+
+// Run the algorithm. Display the elapsed time and solution.
+func runAlgorithm[T Numeric](alg func([]Item[T], T) ([]Item[T], T, int), items []Item[T], allowedWeight T) {
+	// Copy the items so the run isn't influenced by a previous run.
+	testItems := copyItems(items)
+
+	start := time.Now()
+
+	// Run the algorithm.
+	solution, totalValue, functionCalls := alg(testItems, allowedWeight)
+
+	elapsed := time.Since(start)
+
+	fmt.Printf("Elapsed: %f\n", elapsed.Seconds())
+	printSelected(solution)
+	fmt.Printf("Value: %s, Weight: %s, Calls: %d\n",
+		formatValue(float64(totalValue), "%.6g"), formatWeight(float64(sumWeights(solution, false)), "%.6g"),
+		functionCalls)
+	fmt.Printf("Utilization: %.1f%%\n", utilization(solution, allowedWeight))
+	fmt.Println()
+}
+
+// Format a value with -precision and -value-unit.
+// Without -precision, use the format verb the line always used.
+func formatValue(value float64, verb string) string {
+	return formatQuantity(value, verb, *valueUnit)
+}
+
+// Format a weight with -precision and -weight-unit.
+func formatWeight(weight float64, verb string) string {
+	return formatQuantity(weight, verb, *weightUnit)
+}
+
+// Format a number with -precision and a unit label. A one-symbol unit
+// like "$" goes before the number and a word like "kg" goes after it,
+// so the output reads "Value: $82.50, Weight: 62.00 kg".
+func formatQuantity(x float64, verb, unit string) string {
+	if *precision >= 0 {
+		verb = "%." + strconv.Itoa(*precision) + "f"
+	}
+	s := fmt.Sprintf(verb, x)
+
+	if unit == "" {
+		return s
+	}
+	if r, _ := utf8.DecodeRuneInString(unit); utf8.RuneCountInString(unit) == 1 && !unicode.IsLetter(r) {
+		return unit + s
+	}
+	return s + " " + unit
+}
+
+// Return the selected items' weight as a percentage of the allowed weight.
+func utilization[T Numeric](items []Item[T], allowedWeight T) float64 {
+	if allowedWeight <= 0 {
+		return 0
+	}
+	return float64(sumWeights(items, false)) * 100 / float64(allowedWeight)
+}
+
+// Print the selected items.
+func printSelected[T Numeric](items []Item[T]) {
+	numPrinted := 0
+	for i, item := range items {
+		if item.isSelected {
+			fmt.Printf("%d(%.3g, %.3g) ", i, float64(item.value), float64(item.weight))
+		}
+		numPrinted += 1
+		if numPrinted > 100 {
+			fmt.Println("...")
+			return
+		}
+	}
+	fmt.Println()
+}
+
+// Return the value of this solution.
+// If the solution is too heavy, return -1 so we prefer an empty solution.
+func solutionValue[T Numeric](items []Item[T], allowedWeight T) T {
+	if sumWeights(items, false) > allowedWeight {
+		return -1
+	}
+
+	// Return the sum of the selected values.
+	return sumValues(items, false)
+}
+
+// Return the item indices ordered by decreasing value/weight ratio.
+func ratioOrder[T Numeric](items []Item[T]) []int {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := items[order[i]], items[order[j]]
+		return float64(a.value)*float64(b.weight) > float64(b.value)*float64(a.weight)
+	})
+	return order
+}
+
+// Take items in decreasing value/weight order while they fit.
+// Return the assignment, value of that assignment,
+// and the number of items we examined.
+func greedy[T Numeric](items []Item[T], allowedWeight float64) ([]Item[T], T, int) {
+	var totalValue, totalWeight T
+	calls := 0
+	for _, i := range ratioOrder(items) {
+		calls += 1
+		if float64(totalWeight+items[i].weight) <= allowedWeight {
+			items[i].isSelected = true
+			totalValue += items[i].value
+			totalWeight += items[i].weight
+		}
+	}
+	return items, totalValue, calls
+}
+
+// Solve the fractional knapsack, where part of an item may be taken.
+// Whole items are marked as selected. Return the total value, the index of
+// the item taken fractionally (-1 if none), and the fraction of it taken.
+func fractionalKnapsack[T Numeric](items []Item[T], allowedWeight float64) (float64, int, float64) {
+	totalValue := 0.0
+	remaining := allowedWeight
+	for _, i := range ratioOrder(items) {
+		if remaining <= 0 {
+			break
+		}
+		weight := float64(items[i].weight)
+		if weight <= remaining {
+			items[i].isSelected = true
+			totalValue += float64(items[i].value)
+			remaining -= weight
+		} else {
+			fraction := remaining / weight
+			totalValue += fraction * float64(items[i].value)
+			return totalValue, i, fraction
+		}
+	}
+	return totalValue, -1, 0
+}
+
+// Use branch and bound to find a solution.
+// Return the best assignment, value of that assignment,
+// and the number of function calls we made.
+func branchAndBound[T Numeric](items []Item[T], allowedWeight T) ([]Item[T], T, int) {
+	var bestValue, currentValue, currentWeight T
+	remainingValue := sumValues(items, true)
+
+	return doBranchAndBound(items, allowedWeight, 0,
+		bestValue, currentValue, currentWeight, remainingValue)
+}
+
+func doBranchAndBound[T Numeric](items []Item[T], allowedWeight T, nextIndex int,
+	bestValue, currentValue, currentWeight, remainingValue T,
+) ([]Item[T], T, int) {
+	// See if we have a full assignment.
+	if nextIndex >= len(items) {
+		copiedItems := copyItems(items)
+		solutionVal := solutionValue(copiedItems, allowedWeight)
+		return copiedItems, solutionVal, 1
+	}
+
+	// We do not have a full assignment.
+	// See if we can improve this solution enough to be worth pursuing.
+	if currentValue+remainingValue < bestValue {
+		// We cannot improve on the best solution found so far.
+		return nil, 0, 1
+	}
+
+	// Try adding the next item.
+	var test1Solution []Item[T]
+	var test1Value T
+	test1Calls := 1
+	if currentWeight+items[nextIndex].weight <= allowedWeight {
+		items[nextIndex].isSelected = true
+		test1Solution, test1Value, test1Calls = doBranchAndBound(items, allowedWeight, nextIndex+1,
+			bestValue, currentValue+items[nextIndex].value, currentWeight+items[nextIndex].weight, remainingValue-items[nextIndex].value)
+		if test1Value > bestValue {
+			bestValue = test1Value
+		}
+	}
+
+	// Try not adding the next item.
+	var test2Solution []Item[T]
+	var test2Value T
+	test2Calls := 1
+	// See if there is a chance of improvement without this item's value.
+	if currentValue+remainingValue-items[nextIndex].value > bestValue {
+		items[nextIndex].isSelected = false
+		test2Solution, test2Value, test2Calls = doBranchAndBound(items, allowedWeight, nextIndex+1,
+			bestValue, currentValue, currentWeight, remainingValue-items[nextIndex].value)
+	}
+
+	// Return the solution that is better.
+	if test1Value >= test2Value {
+		return test1Solution, test1Value, test1Calls + test2Calls + 1
+	}
+	return test2Solution, test2Value, test1Calls + test2Calls + 1
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Check greedy, fractional and branch and bound on the classic instance
+// of three items with value/weight ratios 6, 5 and 4, scaled by scale.
+// The best packing is the last two items, which greedy misses.
+func checkClassicInstance[T Numeric](t *testing.T, values, weights []T, scale float64) {
+	t.Helper()
+	items := make([]Item[T], len(values))
+	for i := range items {
+		items[i] = Item[T]{values[i], weights[i], false}
+	}
+	allowedWeight := T(50 * scale)
+
+	_, greedyValue, _ := greedy(copyItems(items), float64(allowedWeight))
+	if !approxEqual(float64(greedyValue), 160*scale) {
+		t.Errorf("greedy value %v, want %v", greedyValue, 160*scale)
+	}
+
+	lpValue, critical, fraction := fractionalKnapsack(copyItems(items), float64(allowedWeight))
+	if !approxEqual(lpValue, 240*scale) || critical != 2 || !approxEqual(fraction, 2.0/3) {
+		t.Errorf("fractional value %v, item %d, fraction %v, want %v, 2, 2/3", lpValue, critical, fraction, 240*scale)
+	}
+
+	solution, bnbValue, _ := branchAndBound(copyItems(items), allowedWeight)
+	if !approxEqual(float64(bnbValue), 220*scale) {
+		t.Errorf("branch and bound value %v, want %v", bnbValue, 220*scale)
+	}
+	if solution[0].isSelected || !solution[1].isSelected || !solution[2].isSelected {
+		t.Errorf("branch and bound selected %v, want the last two items", solution)
+	}
+	if sumWeights(solution, false) > allowedWeight {
+		t.Errorf("branch and bound weight %v exceeds %v", sumWeights(solution, false), allowedWeight)
+	}
+}
+
+// Return true if a and b are equal but for rounding error.
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+}
+
+func TestIntItems(t *testing.T) {
+	checkClassicInstance(t, []int{60, 100, 120}, []int{10, 20, 30}, 1)
+}
+
+func TestFloatItems(t *testing.T) {
+	checkClassicInstance(t, []float64{4.5, 7.5, 9}, []float64{0.75, 1.5, 2.25}, 0.075)
+}
+
+func TestFloatBranchAndBoundIsOptimal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		items := make([]Item[float64], 10)
+		for i := range items {
+			items[i] = Item[float64]{random.Float64() * 10, random.Float64()*5 + 0.5, false}
+		}
+		allowedWeight := sumWeights(items, true) / 2
+
+		// Try every subset.
+		best := 0.0
+		for mask := 0; mask < 1<<len(items); mask++ {
+			value, weight := 0.0, 0.0
+			for i, item := range items {
+				if mask&(1<<i) != 0 {
+					value += item.value
+					weight += item.weight
+				}
+			}
+			if weight <= allowedWeight {
+				best = math.Max(best, value)
+			}
+		}
+
+		solution, value, _ := branchAndBound(copyItems(items), allowedWeight)
+		if !approxEqual(value, best) {
+			t.Errorf("trial %d: branch and bound value %v, want %v", trial, value, best)
+		}
+		if sumWeights(solution, false) > allowedWeight {
+			t.Errorf("trial %d: weight %v exceeds %v", trial, sumWeights(solution, false), allowedWeight)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ppichugin/manning-knapsack-problem/internal/instance"
+	"github.com/ppichugin/manning-knapsack-problem/internal/packing"
+)
+
+// Pack a knapsack by hand and compare the result with the solvers.
+//
+//	add <id>       pack an item
+//	remove <id>    unpack an item
+//	solve <algo>   show what greedy or dp would pack
+//	show           list the items, marking the packed ones
+//	reset          unpack everything
+//	help, quit
+
+const minValue = 1
+const maxValue = 10
+const minWeight = 4
+const maxWeight = 10
+
+var numItems = flag.Int("num-items", 10,
+	"the number of random items to make")
+var seed = flag.Int64("seed", 1337,
+	"the seed for the random items")
+var capacityFraction = flag.Float64("capacity-fraction", 0.5,
+	"allowed weight as a fraction of the items' total weight")
+var jsonFile = flag.String("json", "",
+	"load the items and allowed weight from this JSON file instead")
+
+func main() {
+	flag.Parse()
+	if *capacityFraction < 0 {
+		fmt.Fprintln(os.Stderr, "-capacity-fraction must not be negative")
+		os.Exit(2)
+	}
+
+	var items []packing.Item
+	var allowedWeight int
+	if *jsonFile != "" {
+		var err error
+		items, allowedWeight, err = loadItemsJSON(*jsonFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	} else {
+		items = makeItems(*numItems, *seed)
+		allowedWeight = int(float64(packing.SumWeights(items, true)) * *capacityFraction)
+	}
+
+	repl(os.Stdin, os.Stdout, items, allowedWeight)
+}
+
+// Read commands from r until it ends or says quit, writing to w.
+func repl(r io.Reader, w io.Writer, items []packing.Item, allowedWeight int) {
+	packer := packing.NewPacker(allowedWeight)
+	showItems(w, items, packer)
+
+	scanner := bufio.NewScanner(r)
+	fmt.Fprint(w, "> ")
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 {
+			if fields[0] == "quit" || fields[0] == "exit" {
+				return
+			}
+			runCommand(w, fields, items, packer)
+		}
+		fmt.Fprint(w, "> ")
+	}
+}
+
+// Run one command.
+func runCommand(w io.Writer, fields []string, items []packing.Item, packer *packing.Packer) {
+	// Return the item named by the command's argument, or false.
+	itemArg := func() (packing.Item, bool) {
+		if len(fields) != 2 {
+			fmt.Fprintf(w, "usage: %s <id>\n", fields[0])
+			return packing.Item{}, false
+		}
+		id, err := strconv.Atoi(fields[1])
+		if err != nil || id < 0 || id >= len(items) {
+			fmt.Fprintf(w, "no item %q\n", fields[1])
+			return packing.Item{}, false
+		}
+		return items[id], true
+	}
+
+	switch fields[0] {
+	case "add":
+		if item, ok := itemArg(); ok {
+			if !packer.Add(item) {
+				fmt.Fprintf(w, "item %d is already packed or doesn't fit (%d remaining)\n",
+					item.ID, packer.RemainingCapacity())
+			}
+			showPacking(w, packer)
+		}
+	case "remove":
+		if item, ok := itemArg(); ok {
+			if !packer.Remove(item.ID) {
+				fmt.Fprintf(w, "item %d isn't packed\n", item.ID)
+			}
+			showPacking(w, packer)
+		}
+	case "solve":
+		algorithms := map[string]func([]packing.Item, int) ([]packing.Item, int){
+			"greedy": packing.Greedy,
+			"dp":     packing.DynamicProgramming,
+		}
+		if len(fields) != 2 || algorithms[fields[1]] == nil {
+			fmt.Fprintln(w, "usage: solve greedy|dp")
+			return
+		}
+		solution, value := algorithms[fields[1]](packing.CopyItems(items), packer.Capacity())
+		fmt.Fprintf(w, "%s packs %v, Value: %d, Weight: %d\n",
+			fields[1], packing.SelectedIDs(solution), value, packing.SumWeights(solution, false))
+		fmt.Fprintf(w, "Yours is worth %d, %d less\n", packer.Value(), value-packer.Value())
+	case "show":
+		showItems(w, items, packer)
+	case "reset":
+		for _, item := range packer.Items() {
+			packer.Remove(item.ID)
+		}
+		showPacking(w, packer)
+	case "help":
+		fmt.Fprintln(w, "commands: add <id>, remove <id>, solve greedy|dp, show, reset, quit")
+	default:
+		fmt.Fprintf(w, "unknown command %q, try help\n", fields[0])
+	}
+}
+
+// Print every item, marking the packed ones, and then the packing.
+func showItems(w io.Writer, items []packing.Item, packer *packing.Packer) {
+	for _, item := range items {
+		mark := " "
+		if packer.Contains(item.ID) {
+			mark = "*"
+		}
+		fmt.Fprintf(w, "%s %2d (%d, %d) %s\n", mark, item.ID, item.Value, item.Weight, item.Name)
+	}
+	showPacking(w, packer)
+}
+
+// Print the packed items' value and weight. The packer never lets
+// the knapsack get too heavy, so the packing is always feasible.
+func showPacking(w io.Writer, packer *packing.Packer) {
+	fmt.Fprintf(w, "Packed %v, Value: %d, Weight: %d of %d, feasible\n",
+		packing.SelectedIDs(packer.Items()), packer.Value(), packer.Weight(), packer.Capacity())
+}
+
+// Make some random items from the seed.
+func makeItems(numItems int, seed int64) []packing.Item {
+	random := rand.New(rand.NewSource(seed))
+	items := make([]packing.Item, numItems)
+	for i := range items {
+		items[i] = packing.Item{ID: i,
+			Value:  random.Intn(maxValue-minValue+1) + minValue,
+			Weight: random.Intn(maxWeight-minWeight+1) + minWeight}
+	}
+	return items
+}
+
+// Read a JSON instance in the form instance.Read reads.
+// Return the items, numbered in file order, and the allowed weight.
+func loadItemsJSON(path string) ([]packing.Item, int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+	knapsack, err := instance.Read(file)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items := make([]packing.Item, len(knapsack.Items))
+	for i, item := range knapsack.Items {
+		items[i] = packing.Item{ID: i, Value: item.Value, Weight: item.Weight, Name: item.Name}
+	}
+	return items, knapsack.Capacity, nil
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math/rand"
+	"os"
 	"sort"
 	"time"
 )
@@ -18,6 +20,11 @@ const maxWeight = 10
 
 var allowedWeight int
 
+var capacityFraction = flag.Float64("capacity-fraction", 0.5,
+	"allowed weight as a fraction of the items' total weight")
+var orderName = flag.String("order", "blocklist",
+	"item order for sorted Rod's technique: blocklist, ratio, or blocklist-ratio")
+
 type Item struct {
 	id, blockedBy int
 	blockList     []int // Other items that this one blocks.
@@ -26,6 +33,11 @@ type Item struct {
 }
 
 // Test results:
+// makeItems uses a changing seed, so these are only examples.
+// Each run makes different items and gets different values.
+// The sorted run below printed its items in sorted order; sorted
+// solutions now list the items by their original ids instead.
+//
 // *** Parameters ***
 // # items: 80
 // Total value: 470
@@ -47,8 +59,19 @@ type Item struct {
 // Value: 340, Weight: 267, Calls: 209529
 
 func main() {
+	flag.Parse()
+
 	items := makeItems(numItems, minValue, maxValue, minWeight, maxWeight)
-	allowedWeight = sumWeights(items, true) / 2
+	if *capacityFraction < 0 {
+		fmt.Fprintln(os.Stderr, "-capacity-fraction must not be negative")
+		os.Exit(2)
+	}
+	allowedWeight = int(float64(sumWeights(items, true)) * *capacityFraction)
+	order, ok := itemOrderNames[*orderName]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown -order %q\n", *orderName)
+		os.Exit(2)
+	}
 
 	// Display basic parameters.
 	fmt.Println("*** Parameters ***")
@@ -60,7 +83,8 @@ func main() {
 
 	// Exhaustive search
 	if numItems > 25 { // Only run exhaustive search if numItems <= 25.
-		fmt.Println("Too many items for exhaustive search\n")
+		fmt.Println("Too many items for exhaustive search")
+		fmt.Println()
 	} else {
 		fmt.Println("*** Exhaustive Search ***")
 		runAlgorithm(exhaustiveSearch, items, allowedWeight)
@@ -68,7 +92,8 @@ func main() {
 
 	// Branch and bound
 	if numItems > 45 { // Only run branch and bound if numItems <= 45.
-		fmt.Println("Too many items for branch and bound\n")
+		fmt.Println("Too many items for branch and bound")
+		fmt.Println()
 	} else {
 		fmt.Println("*** Branch and Bound ***")
 		runAlgorithm(branchAndBound, items, allowedWeight)
@@ -76,7 +101,8 @@ func main() {
 
 	// Rod's technique
 	if numItems > 85 { // Only use Rod's technique if numItems <= 85.
-		fmt.Println("Too many items for Rod's technique\n")
+		fmt.Println("Too many items for Rod's technique")
+		fmt.Println()
 	} else {
 		fmt.Println("*** Rod's technique ***")
 		runAlgorithm(rodsTechnique, items, allowedWeight)
@@ -84,10 +110,13 @@ func main() {
 
 	// Rod's technique sorted
 	if numItems > 350 { // Only use Rod's technique if numItems <= 350.
-		fmt.Println("Too many items for Rod's technique\n")
+		fmt.Println("Too many items for Rod's technique")
+		fmt.Println()
 	} else {
-		fmt.Println("*** Rod's technique Sorted ***")
-		runAlgorithm(rodsTechniqueSorted, items, allowedWeight)
+		fmt.Printf("*** Rod's technique Sorted by %s ***\n", *orderName)
+		runAlgorithm(func(items []Item, allowedWeight int) ([]Item, int, int) {
+			return rodsTechniqueOrdered(items, allowedWeight, order)
+		}, items, allowedWeight)
 	}
 }
 
@@ -141,12 +170,25 @@ func unblockItems(source Item, items []Item) {
 }
 
 // Return a copy of the items slice.
+// The copies share their block lists with the originals.
 func copyItems(items []Item) []Item {
 	newItems := make([]Item, len(items))
 	copy(newItems, items)
 	return newItems
 }
 
+// Return a copy of the items slice that also copies the block lists,
+// so changing a copy's block list doesn't change the original's.
+func deepCopyItems(items []Item) []Item {
+	newItems := copyItems(items)
+	for i := range newItems {
+		if newItems[i].blockList != nil {
+			newItems[i].blockList = append([]int{}, newItems[i].blockList...)
+		}
+	}
+	return newItems
+}
+
 // Return the total value of the items.
 // If addAll is false, only add up the selected items.
 func sumValues(items []Item, addAll bool) int {
@@ -159,25 +201,45 @@ func sumValues(items []Item, addAll bool) int {
 	return total
 }
 
+// How long the last algorithm spent before its search, building block
+// lists and sorting. runAlgorithm resets it, and the algorithms that
+// preprocess set it, so runAlgorithm can show the two phases apart.
+// The block lists take O(n²) time, which adds up for many items.
+var preprocessTime time.Duration
+
 // Run the algorithm. Display the elapsed time and solution.
 func runAlgorithm(alg func([]Item, int) ([]Item, int, int), items []Item, allowedWeight int) {
 	// Copy the items so the run isn't influenced by a previous run.
-	testItems := copyItems(items)
+	testItems := deepCopyItems(items)
 
 	start := time.Now()
 
 	// Run the algorithm.
+	preprocessTime = 0
 	solution, totalValue, functionCalls := alg(testItems, allowedWeight)
 
 	elapsed := time.Since(start)
 
 	fmt.Printf("Elapsed: %f\n", elapsed.Seconds())
+	if preprocessTime > 0 {
+		fmt.Printf("Preprocess: %f, Search: %f\n",
+			preprocessTime.Seconds(), (elapsed - preprocessTime).Seconds())
+	}
 	printSelected(solution)
 	fmt.Printf("Value: %d, Weight: %d, Calls: %d\n",
 		totalValue, sumWeights(solution, false), functionCalls)
+	fmt.Printf("Utilization: %.1f%%\n", utilization(solution, allowedWeight))
 	fmt.Println()
 }
 
+// Return the selected items' weight as a percentage of the allowed weight.
+func utilization(items []Item, allowedWeight int) float64 {
+	if allowedWeight <= 0 {
+		return 0
+	}
+	return float64(sumWeights(items, false)) * 100 / float64(allowedWeight)
+}
+
 // Print the selected items.
 func printSelected(items []Item) {
 	numPrinted := 0
@@ -322,7 +384,9 @@ func doBranchAndBound(items []Item, allowedWeight, nextIndex,
 }
 
 func rodsTechnique(items []Item, allowedWeight int) ([]Item, int, int) {
+	start := time.Now()
 	makeBlockLists(items)
+	preprocessTime = time.Since(start)
 
 	bestValue := 0
 	currentValue := 0
@@ -385,27 +449,98 @@ func doRodsTechnique(items []Item, allowedWeight, nextIndex,
 	}
 }
 
+// The order rodsTechniqueSorted puts the items in before searching.
+// Which order prunes best depends on the items. Calls for 80 items made
+// with the seeds 1, 2, 3, and 1337:
+//
+//	blocklist:        262983  481947  265259  179573
+//	ratio:            176771  503907  141991  134189
+//	blocklist-ratio:  255641  428689  266029  175515
+type itemOrder int
+
+const (
+	byBlockList          itemOrder = iota // Longest block list first.
+	byRatio                               // Highest value/weight ratio first.
+	byBlockListThenRatio                  // Longest block list first, ties by ratio.
+)
+
+var itemOrderNames = map[string]itemOrder{
+	"blocklist":       byBlockList,
+	"ratio":           byRatio,
+	"blocklist-ratio": byBlockListThenRatio,
+}
+
 func rodsTechniqueSorted(items []Item, allowedWeight int) ([]Item, int, int) {
-	makeBlockLists(items)
+	return rodsTechniqueOrdered(items, allowedWeight, byBlockList)
+}
+
+// Sort the items into the given order. Ties are broken by
+// value/weight ratio where the order says so, then by id, so sorting
+// the same items always gives the same order and the same Calls.
+func sortItems(items []Item, order itemOrder) {
+	// Return true if item a has a higher value/weight ratio than item b.
+	higherRatio := func(a, b Item) bool {
+		return a.value*b.weight > b.value*a.weight
+	}
+	sameRatio := func(a, b Item) bool {
+		return a.value*b.weight == b.value*a.weight
+	}
 
-	// Sort so items with longer blocked lists come first.
-	sort.Slice(items, func(i, j int) bool {
-		return len(items[i].blockList) > len(items[j].blockList)
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if order != byRatio && len(a.blockList) != len(b.blockList) {
+			// Items with longer block lists come first.
+			return len(a.blockList) > len(b.blockList)
+		}
+		if order != byBlockList && !sameRatio(a, b) {
+			return higherRatio(a, b)
+		}
+		return a.id < b.id
 	})
+}
 
-	// Reset the items' IDs.
+// Use Rod's technique after sorting the items into the given order.
+func rodsTechniqueOrdered(items []Item, allowedWeight int, order itemOrder) ([]Item, int, int) {
+	start := time.Now()
+	makeBlockLists(items)
+
+	sortItems(items, order)
+
+	// Reset the items' IDs, remembering the original ones.
+	originalIDs := make([]int, len(items))
 	for i := range items {
+		originalIDs[i] = items[i].id
 		items[i].id = i
 	}
 
 	// Rebuild the blocked lists with the new indices.
 	makeBlockLists(items)
+	preprocessTime = time.Since(start)
 
 	bestValue := 0
 	currentValue := 0
 	currentWeight := 0
 	remainingValue := sumValues(items, true)
 
-	return doRodsTechnique(items, allowedWeight, 0,
+	solution, totalValue, functionCalls := doRodsTechnique(items, allowedWeight, 0,
 		bestValue, currentValue, currentWeight, remainingValue)
+	if solution != nil {
+		// Report the solution in terms of the items as they came in.
+		solution = restoreOrder(solution, originalIDs)
+	}
+	return solution, totalValue, functionCalls
+}
+
+// Give the sorted items back their original ids and put them
+// in id order, which is the order makeItems made them in.
+// The block lists still use the sorted ids, so don't search the result.
+func restoreOrder(sorted []Item, originalIDs []int) []Item {
+	restored := copyItems(sorted)
+	for i := range restored {
+		restored[i].id = originalIDs[i]
+	}
+	sort.SliceStable(restored, func(i, j int) bool {
+		return restored[i].id < restored[j].id
+	})
+	return restored
 }
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ppichugin/manning-knapsack-problem/internal/instance"
+)
+
+// Check a solution from another tool, or a submitted homework answer,
+// against a JSON instance like the one dynamic-programming reads with -json.
+// The solution file holds the ids of the selected items, which are their
+// positions in the instance, separated by spaces, commas, or newlines.
+// A JSON array like [0, 3, 7] works too.
+//
+// Exit codes:
+//
+//	0: feasible, and optimal or too big to check
+//	1: infeasible
+//	2: the files couldn't be read
+//	3: feasible but not optimal
+
+// Only run dynamic programming if its table has at most this many cells.
+const maxTableCells = 50_000_000
+
+var instanceFile = flag.String("instance", "",
+	"the JSON instance: {\"capacity\": 62, \"items\": [{\"value\": 9, \"weight\": 5}, ...]}")
+var solutionFile = flag.String("solution", "",
+	"the file holding the selected item ids")
+
+func main() {
+	flag.Parse()
+	if *instanceFile == "" || *solutionFile == "" {
+		fmt.Fprintln(os.Stderr, "usage: verify -instance instance.json -solution ids.txt")
+		os.Exit(2)
+	}
+
+	knapsack, err := loadInstance(*instanceFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	selected, err := loadSelection(*solutionFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	value, weight, err := knapsack.Check(selected)
+	fmt.Printf("Items selected: %d\n", len(selected))
+	fmt.Printf("Value: %d, Weight: %d, Allowed weight: %d\n", value, weight, knapsack.Capacity)
+	if err != nil {
+		fmt.Printf("Verdict: INFEASIBLE: %v\n", err)
+		os.Exit(1)
+	}
+
+	if cells := len(knapsack.Items) * (knapsack.Capacity + 1); cells > maxTableCells {
+		fmt.Printf("Verdict: FEASIBLE, optimality not checked (table of %d cells > %d)\n",
+			cells, maxTableCells)
+		return
+	}
+	best := optimalValue(knapsack.Items, knapsack.Capacity)
+	if value < best {
+		fmt.Printf("Verdict: FEASIBLE but not optimal: value %d, optimum %d (%d short)\n",
+			value, best, best-value)
+		os.Exit(3)
+	}
+	fmt.Printf("Verdict: OPTIMAL: value %d\n", value)
+}
+
+// Read a JSON instance in the form instance.Read reads.
+func loadInstance(path string) (instance.Instance, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return instance.Instance{}, err
+	}
+	defer file.Close()
+	return instance.Read(file)
+}
+
+// Read the selected item ids.
+func loadSelection(path string) ([]int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.FieldsFunc(string(data), func(r rune) bool {
+		return r == ',' || r == '[' || r == ']' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+
+	selected := []int{}
+	for _, field := range fields {
+		id, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("solution: %q is not an item id", field)
+		}
+		selected = append(selected, id)
+	}
+	return selected, nil
+}
+
+// Use dynamic programming to find the best value of any selection.
+// Only the best value is needed, so one row of the table is enough.
+// Items with negative values never help, so they're skipped.
+func optimalValue(items []instance.Item, allowedWeight int) int {
+	best := make([]int, allowedWeight+1)
+	for _, item := range items {
+		if item.Value < 0 {
+			continue
+		}
+		// Go down so each item is used at most once.
+		for w := allowedWeight; w >= item.Weight; w-- {
+			best[w] = max(best[w], best[w-item.Weight]+item.Value)
+		}
+	}
+	return best[allowedWeight]
+}
@@ -0,0 +1,20 @@
+package instance
+
+import "errors"
+
+// Errors that callers can check for with errors.Is.
+// The functions that return them wrap them with the details.
+var (
+	// The allowed weight or a knapsack capacity is negative.
+	ErrNegativeCapacity = errors.New("negative capacity")
+
+	// An instance has no items.
+	ErrEmptyItems = errors.New("no items")
+
+	// The items a solution must include, with their prerequisites,
+	// weigh more than the capacity.
+	ErrInfeasibleForcedItems = errors.New("forced items don't fit")
+
+	// A table-based algorithm would need more cells than its limit.
+	ErrTableTooLarge = errors.New("table too large")
+)
@@ -0,0 +1,111 @@
+// Package instance reads and writes knapsack instances in the JSON form
+// that the commands share, and checks selections against them.
+package instance
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// The JSON form of a knapsack instance:
+//
+//	{"capacity": 62, "items": [{"value": 9, "weight": 5, "name": "tent", "group": 1, "cost": 120}, ...]}
+//
+// Name, group, cost, priority, requires, and value2 are optional. A negative
+// value is a penalty, so the solvers never select that item. Priority
+// defaults to 1. Requires lists the positions of the items that must be
+// selected for this one to be. Value2 is a second kind of value that
+// dynamic-programming's max-min solver balances against the first.
+// An item's id is its position in the list.
+type Instance struct {
+	Capacity int    `json:"capacity"`
+	Items    []Item `json:"items"`
+}
+
+type Item struct {
+	Value  int    `json:"value"`
+	Weight int    `json:"weight"`
+	Name   string `json:"name,omitempty"`
+	Group  int    `json:"group,omitempty"`
+	Cost   int    `json:"cost,omitempty"`
+	// A pointer so a missing priority can default to 1 while 0 still means 0.
+	Priority *float64 `json:"priority,omitempty"`
+	Requires []int    `json:"requires,omitempty"`
+	Value2   int      `json:"value2,omitempty"`
+}
+
+// Read a JSON instance and check that it makes sense: nothing is
+// negative that can't be, and every required item exists.
+// Unknown fields are an error, so a misspelled one isn't silently ignored.
+func Read(r io.Reader) (Instance, error) {
+	var instance Instance
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&instance); err != nil {
+		return Instance{}, fmt.Errorf("reading JSON instance: %w", err)
+	}
+
+	if instance.Capacity < 0 {
+		return Instance{}, fmt.Errorf("%w: %d", ErrNegativeCapacity, instance.Capacity)
+	}
+	for i, item := range instance.Items {
+		if item.Weight < 0 {
+			return Instance{}, fmt.Errorf("item %d %q has negative weight %d", i, item.Name, item.Weight)
+		}
+		if item.Group < 0 {
+			return Instance{}, fmt.Errorf("item %d %q has negative group %d", i, item.Name, item.Group)
+		}
+		if item.Cost < 0 {
+			return Instance{}, fmt.Errorf("item %d %q has negative cost %d", i, item.Name, item.Cost)
+		}
+		if item.Priority != nil && *item.Priority < 0 {
+			return Instance{}, fmt.Errorf("item %d %q has negative priority %g", i, item.Name, *item.Priority)
+		}
+		for _, r := range item.Requires {
+			if r < 0 || r >= len(instance.Items) {
+				return Instance{}, fmt.Errorf("item %d %q requires item %d, which doesn't exist", i, item.Name, r)
+			}
+		}
+	}
+	return instance, nil
+}
+
+// Write the instance in the form Read reads.
+func (instance Instance) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(instance)
+}
+
+// Return the item's priority, which defaults to 1.
+func (item Item) PriorityOrDefault() float64 {
+	if item.Priority == nil {
+		return 1
+	}
+	return *item.Priority
+}
+
+// Check that the selected ids name distinct items that fit in the capacity.
+// Return the selected items' value and weight, and an error saying why
+// the selection is infeasible, if it is. If it is, the value and weight
+// are those of the items up to the one that made it so.
+func (instance Instance) Check(selected []int) (int, int, error) {
+	value, weight := 0, 0
+	seen := make(map[int]bool)
+	for _, id := range selected {
+		if id < 0 || id >= len(instance.Items) {
+			return value, weight, fmt.Errorf("item %d doesn't exist", id)
+		}
+		if seen[id] {
+			return value, weight, fmt.Errorf("item %d is selected twice", id)
+		}
+		seen[id] = true
+		value += instance.Items[id].Value
+		weight += instance.Items[id].Weight
+	}
+	if weight > instance.Capacity {
+		return value, weight, fmt.Errorf("weight %d exceeds capacity %d", weight, instance.Capacity)
+	}
+	return value, weight, nil
+}
@@ -0,0 +1,49 @@
+package instance
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	knapsack := Instance{Capacity: 10, Items: []Item{{Value: 9, Weight: 5}, {Value: 10, Weight: 4}, {Value: 7, Weight: 5}}}
+	for _, test := range []struct {
+		selected      []int
+		value, weight int
+		err           string
+	}{
+		{[]int{0, 1}, 19, 9, ""},
+		{[]int{}, 0, 0, ""},
+		{[]int{0, 3}, 9, 5, "item 3 doesn't exist"},
+		{[]int{1, 1}, 10, 4, "item 1 is selected twice"},
+		{[]int{0, 1, 2}, 26, 14, "weight 14 exceeds capacity 10"},
+	} {
+		value, weight, err := knapsack.Check(test.selected)
+		if value != test.value || weight != test.weight {
+			t.Errorf("%v: value %d and weight %d, want %d and %d", test.selected, value, weight, test.value, test.weight)
+		}
+		if (err == nil) != (test.err == "") || err != nil && err.Error() != test.err {
+			t.Errorf("%v: error %v, want %q", test.selected, err, test.err)
+		}
+	}
+}
+
+func TestReadRejects(t *testing.T) {
+	for _, test := range []struct{ json, err string }{
+		{`{"capacity": -1, "items": []}`, "negative capacity: -1"},
+		{`{"capacity": 5, "items": [{"value": 1, "weight": -2}]}`, "negative weight"},
+		{`{"capacity": 5, "items": [{"value": 1, "weight": 2, "requires": [1]}]}`, "requires item 1"},
+		{`{"capacity": 5, "items": [{"value": 1, "wieght": 2}]}`, "unknown field"},
+	} {
+		_, err := Read(strings.NewReader(test.json))
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: error %v, want one containing %q", test.json, err, test.err)
+		}
+	}
+
+	_, err := Read(strings.NewReader(`{"capacity": -1, "items": []}`))
+	if !errors.Is(err, ErrNegativeCapacity) {
+		t.Errorf("error %v isn't ErrNegativeCapacity", err)
+	}
+}
@@ -0,0 +1,81 @@
+package packing
+
+// A Packer fills a knapsack by hand, one item at a time.
+type Packer struct {
+	allowedWeight int
+	items         []Item // The packed items.
+	value, weight int
+}
+
+// Return an empty packer for a knapsack that can hold allowedWeight.
+func NewPacker(allowedWeight int) *Packer {
+	return &Packer{allowedWeight: allowedWeight}
+}
+
+// Add the item to the knapsack.
+// Return false if it is already packed or would make the knapsack too heavy.
+func (p *Packer) Add(item Item) bool {
+	if p.indexOf(item.ID) >= 0 || p.weight+item.Weight > p.allowedWeight {
+		return false
+	}
+
+	item.Selected = true
+	p.items = append(p.items, item)
+	p.value += item.Value
+	p.weight += item.Weight
+	return true
+}
+
+// Take the item with this id out of the knapsack.
+// Return false if it wasn't packed.
+func (p *Packer) Remove(id int) bool {
+	i := p.indexOf(id)
+	if i < 0 {
+		return false
+	}
+
+	p.value -= p.items[i].Value
+	p.weight -= p.items[i].Weight
+	p.items = append(p.items[:i], p.items[i+1:]...)
+	return true
+}
+
+// Return true if the item with this id is packed.
+func (p *Packer) Contains(id int) bool {
+	return p.indexOf(id) >= 0
+}
+
+// Return the total value of the packed items.
+func (p *Packer) Value() int {
+	return p.value
+}
+
+// Return the total weight of the packed items.
+func (p *Packer) Weight() int {
+	return p.weight
+}
+
+// Return the weight the knapsack can hold.
+func (p *Packer) Capacity() int {
+	return p.allowedWeight
+}
+
+// Return how much more weight the knapsack can hold.
+func (p *Packer) RemainingCapacity() int {
+	return p.allowedWeight - p.weight
+}
+
+// Return a copy of the packed items in the order they were added.
+func (p *Packer) Items() []Item {
+	return CopyItems(p.items)
+}
+
+// Return the index of the packed item with this id, or -1 if it isn't packed.
+func (p *Packer) indexOf(id int) int {
+	for i := range p.items {
+		if p.items[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
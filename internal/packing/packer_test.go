@@ -0,0 +1,57 @@
+package packing
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPackerRejectsItemsPastCapacity(t *testing.T) {
+	packer := NewPacker(10)
+	if !packer.Add(Item{ID: 0, Value: 9, Weight: 6}) {
+		t.Fatal("the first item didn't fit in an empty knapsack")
+	}
+	if packer.Add(Item{ID: 1, Value: 10, Weight: 5}) {
+		t.Error("added an item past the capacity")
+	}
+	if packer.Add(Item{ID: 0, Value: 9, Weight: 6}) {
+		t.Error("added the same item twice")
+	}
+	if !packer.Add(Item{ID: 2, Value: 3, Weight: 4}) {
+		t.Error("an item that exactly fills the knapsack didn't fit")
+	}
+	if packer.Value() != 12 || packer.Weight() != 10 || packer.RemainingCapacity() != 0 {
+		t.Errorf("value %d, weight %d, remaining %d, want 12, 10 and 0",
+			packer.Value(), packer.Weight(), packer.RemainingCapacity())
+	}
+}
+
+func TestPackerRemoveThenAddRestoresTotals(t *testing.T) {
+	packer := NewPacker(20)
+	items := []Item{{ID: 0, Value: 9, Weight: 5}, {ID: 1, Value: 10, Weight: 4}, {ID: 2, Value: 7, Weight: 5}}
+	for _, item := range items {
+		packer.Add(item)
+	}
+	value, weight := packer.Value(), packer.Weight()
+
+	if !packer.Remove(1) {
+		t.Fatal("couldn't remove a packed item")
+	}
+	if packer.Remove(1) {
+		t.Error("removed an item that isn't packed")
+	}
+	if packer.Value() != value-10 || packer.Weight() != weight-4 {
+		t.Errorf("after the removal, value %d and weight %d, want %d and %d",
+			packer.Value(), packer.Weight(), value-10, weight-4)
+	}
+
+	if !packer.Add(items[1]) {
+		t.Fatal("couldn't add the removed item back")
+	}
+	if packer.Value() != value || packer.Weight() != weight || packer.RemainingCapacity() != 20-weight {
+		t.Errorf("after adding it back, value %d, weight %d, remaining %d, want %d, %d and %d",
+			packer.Value(), packer.Weight(), packer.RemainingCapacity(), value, weight, 20-weight)
+	}
+	if got := SelectedIDs(packer.Items()); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("packed %v, want [0 1 2]", got)
+	}
+}
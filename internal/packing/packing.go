@@ -0,0 +1,61 @@
+// Package packing holds the items, the hand packer, and the reference
+// solvers that more than one command uses, so they can't drift apart.
+package packing
+
+import "sort"
+
+// An item that can go in the knapsack. Its id is its position
+// in the instance.
+type Item struct {
+	ID, Value, Weight int
+	Selected          bool
+	Name              string
+}
+
+// Return a copy of the items slice.
+func CopyItems(items []Item) []Item {
+	newItems := make([]Item, len(items))
+	copy(newItems, items)
+	return newItems
+}
+
+// Return the total weight of the items.
+// If addAll is false, only add up the selected items.
+func SumWeights(items []Item, addAll bool) int {
+	total := 0
+	for i := 0; i < len(items); i++ {
+		if addAll || items[i].Selected {
+			total += items[i].Weight
+		}
+	}
+	return total
+}
+
+// Return the ids of the selected items in increasing order.
+func SelectedIDs(items []Item) []int {
+	ids := []int{}
+	for _, item := range items {
+		if item.Selected {
+			ids = append(ids, item.ID)
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// Return an item's place in value/weight order among items whose ratios
+// can't be compared by cross-multiplying: weightless items with
+// value first, then weighted items, then worthless weightless ones,
+// then penalty items. Without the classes, a weightless item would
+// compare equal to items that differ, which scrambles the sort.
+func RatioClass(value float64, weight int) int {
+	switch {
+	case value < 0:
+		return 3
+	case weight == 0 && value > 0:
+		return 0
+	case weight == 0:
+		return 2
+	}
+	return 1
+}
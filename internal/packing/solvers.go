@@ -0,0 +1,63 @@
+package packing
+
+import "sort"
+
+// The solvers here are the plain ones, without call counts or any of
+// cmd/dynamic-programming's options, for commands that just need an answer.
+
+// Take items in decreasing value/weight order while they fit,
+// skipping the ones that aren't worth anything.
+// Return the assignment and its value.
+func Greedy(items []Item, allowedWeight int) ([]Item, int) {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := items[order[i]], items[order[j]]
+		if ca, cb := RatioClass(float64(a.Value), a.Weight), RatioClass(float64(b.Value), b.Weight); ca != cb {
+			return ca < cb
+		}
+		return a.Value*b.Weight > b.Value*a.Weight
+	})
+
+	totalValue := 0
+	totalWeight := 0
+	for _, i := range order {
+		if items[i].Value > 0 && totalWeight+items[i].Weight <= allowedWeight {
+			items[i].Selected = true
+			totalValue += items[i].Value
+			totalWeight += items[i].Weight
+		}
+	}
+	return items, totalValue
+}
+
+// Use dynamic programming to find the best assignment.
+// Return the assignment and its value.
+func DynamicProgramming(items []Item, allowedWeight int) ([]Item, int) {
+	// best[w] is the best value of the items so far weighing at most w.
+	// took[i][w] is true if item i improved best[w].
+	best := make([]int, allowedWeight+1)
+	took := make([][]bool, len(items))
+	for i, item := range items {
+		took[i] = make([]bool, allowedWeight+1)
+		// Work downwards so item i is only used once.
+		for w := allowedWeight; w >= item.Weight; w-- {
+			if best[w-item.Weight]+item.Value > best[w] {
+				best[w] = best[w-item.Weight] + item.Value
+				took[i][w] = true
+			}
+		}
+	}
+
+	// Work backwards to find the items.
+	w := allowedWeight
+	for i := len(items) - 1; i >= 0; i-- {
+		if took[i][w] {
+			items[i].Selected = true
+			w -= items[i].Weight
+		}
+	}
+	return items, best[allowedWeight]
+}
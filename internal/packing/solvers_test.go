@@ -0,0 +1,59 @@
+package packing
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestGreedyOrdersWeightlessItems(t *testing.T) {
+	// Cross-multiplying calls the worthless weightless item's ratio equal
+	// to everyone's, so without the ratio classes it keeps the better
+	// item 2 from sorting ahead of item 0.
+	items := []Item{{ID: 0, Value: 9, Weight: 5}, {ID: 1, Value: 0, Weight: 0}, {ID: 2, Value: 10, Weight: 5}}
+	solution, value := Greedy(CopyItems(items), 5)
+	if got := SelectedIDs(solution); value != 10 || !slices.Equal(got, []int{2}) {
+		t.Errorf("selected %v worth %d, want [2] worth 10", got, value)
+	}
+}
+
+func TestDynamicProgrammingIsOptimal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for run := 0; run < 50; run++ {
+		items := make([]Item, 10)
+		for i := range items {
+			items[i] = Item{ID: i, Value: random.Intn(21) - 5, Weight: random.Intn(8)}
+		}
+		allowedWeight := SumWeights(items, true) / 2
+
+		solution, value := DynamicProgramming(CopyItems(items), allowedWeight)
+		if weight := SumWeights(solution, false); weight > allowedWeight {
+			t.Fatalf("run %d: selected weight %d exceeds %d", run, weight, allowedWeight)
+		}
+		if want := bestValue(items, allowedWeight); value != want {
+			t.Errorf("run %d: value %d, want %d", run, value, want)
+		}
+		if greedySolution, greedyValue := Greedy(CopyItems(items), allowedWeight); greedyValue > value ||
+			SumWeights(greedySolution, false) > allowedWeight {
+			t.Errorf("run %d: greedy found value %d, beating the optimum %d or overfilling", run, greedyValue, value)
+		}
+	}
+}
+
+// Return the best value of any selection of the items, by trying them all.
+func bestValue(items []Item, allowedWeight int) int {
+	best := 0
+	for mask := 0; mask < 1<<len(items); mask++ {
+		value, weight := 0, 0
+		for i, item := range items {
+			if mask&(1<<i) != 0 {
+				value += item.Value
+				weight += item.Weight
+			}
+		}
+		if weight <= allowedWeight {
+			best = max(best, value)
+		}
+	}
+	return best
+}